@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"html"
 	"io"
@@ -11,6 +10,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/IanShearer/aoc/aocanswer"
 )
 
 func main() {
@@ -239,22 +240,12 @@ func redactDay() {
 }
 
 func readAnswers(path string) ([]string, error) {
-	file, err := os.Open(path)
+	file, err := aocanswer.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	var answers []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			answers = append(answers, line)
-		}
-	}
 
-	return answers, scanner.Err()
+	return file.Accepted(), nil
 }
 
 func redactPuzzleBlocks(content string, dayNum int) string {
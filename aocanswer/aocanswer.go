@@ -0,0 +1,126 @@
+// Package aocanswer loads the answers of a day from its answers file, which
+// is gitignored, so solutions and tests can check against the real answer
+// without committing it.
+//
+// The answers file has the accepted answer of each part, in the structured
+// format of File or one answer per line, part one first. It is looked for in
+// the working directory and its parents, so it is found from dayNN/ai and
+// dayNN/human. Without it String and Int panic, while TestString and TestInt
+// skip the test.
+package aocanswer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// Integer is any integer type an answer can be compared with.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+var (
+	once    sync.Once
+	answers []string
+	loadErr error
+)
+
+// String returns the answer of a part, 1 or 2.
+func String(part int) string {
+	s, err := lookup(part)
+	if err != nil {
+		panic(fmt.Sprintf("aocanswer: %v", err))
+	}
+
+	return s
+}
+
+// Int returns the answer of a part as a number, e.g. aocanswer.Int[int64](1).
+func Int[T Integer](part int) T {
+	return parseInt[T](part, String(part))
+}
+
+// TB is the part of testing.TB the test helpers need.
+type TB interface {
+	Helper()
+	Skip(args ...any)
+}
+
+// TestString returns the answer of a part in a test, which is skipped when
+// there is no answer, like in CI or a fresh clone.
+func TestString(t TB, part int) string {
+	t.Helper()
+
+	s, err := lookup(part)
+	if err != nil {
+		t.Skip(err)
+		return ""
+	}
+
+	return s
+}
+
+// TestInt returns the answer of a part as a number in a test, which is
+// skipped when there is no answer, e.g. aocanswer.TestInt[int64](t, 1).
+func TestInt[T Integer](t TB, part int) T {
+	t.Helper()
+
+	s := TestString(t, part)
+	if s == "" {
+		return 0
+	}
+
+	return parseInt[T](part, s)
+}
+
+func lookup(part int) (string, error) {
+	once.Do(func() {
+		answers, loadErr = load()
+	})
+
+	if loadErr != nil {
+		return "", loadErr
+	}
+	if part < 1 || part > len(answers) || answers[part-1] == "" {
+		return "", fmt.Errorf("no answer for part %d", part)
+	}
+
+	return answers[part-1], nil
+}
+
+func parseInt[T Integer](part int, s string) T {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return T(n)
+	}
+
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("aocanswer: part %d answer %q is not a number", part, s))
+	}
+
+	return T(n)
+}
+
+func load() ([]string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	for range 3 {
+		f, err := ReadFile(filepath.Join(dir, "answers"))
+		if err == nil {
+			return f.Accepted(), nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		dir = filepath.Dir(dir)
+	}
+
+	return nil, fmt.Errorf("answers file not found, it is gitignored and only exists where the day was solved")
+}
@@ -0,0 +1,21 @@
+package aocanswer
+
+import "testing"
+
+type skipper struct {
+	skipped []any
+}
+
+func (s *skipper) Helper() {}
+
+func (s *skipper) Skip(args ...any) {
+	s.skipped = args
+}
+
+func TestTestIntSkips(t *testing.T) {
+	// there is no answers file in the package or above it
+	s := &skipper{}
+	if got := TestInt[int](s, 1); got != 0 || s.skipped == nil {
+		t.Errorf("expected the test to be skipped, got %d and %v", got, s.skipped)
+	}
+}
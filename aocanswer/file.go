@@ -0,0 +1,183 @@
+package aocanswer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Feedback the website gives on a wrong answer.
+const (
+	TooHigh = "too high"
+	TooLow  = "too low"
+)
+
+// File is an answers file. The structured format is JSON:
+//
+//	{
+//	  "parts": [
+//	    {"part": 1, "accepted": "1052", "rejected": [{"answer": "1100", "feedback": "too high"}]},
+//	    {"part": 2, "rejected": [{"answer": "6000"}]}
+//	  ]
+//	}
+//
+// The plain format, an accepted answer per line from part one on, is read as
+// the same thing.
+type File struct {
+	Parts []Part `json:"parts"`
+}
+
+// Part is what is known about the answer of a part.
+type Part struct {
+	Part     int     `json:"part"`
+	Accepted string  `json:"accepted,omitempty"`
+	Rejected []Guess `json:"rejected,omitempty"`
+}
+
+// Guess is a rejected answer, with the website's feedback when it gave any.
+type Guess struct {
+	Answer   string `json:"answer"`
+	Feedback string `json:"feedback,omitempty"`
+}
+
+// ReadFile reads an answers file in either format.
+func ReadFile(path string) (*File, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return f, nil
+}
+
+// Parse parses an answers file, structured when it starts with {.
+func Parse(b []byte) (*File, error) {
+	f := &File{}
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		if err := json.Unmarshal(b, f); err != nil {
+			return nil, err
+		}
+		for _, p := range f.Parts {
+			if p.Part < 1 {
+				return nil, fmt.Errorf("part %d, parts are numbered from 1", p.Part)
+			}
+		}
+		return f, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			f.Parts = append(f.Parts, Part{Part: len(f.Parts) + 1, Accepted: line})
+		}
+	}
+
+	return f, scanner.Err()
+}
+
+// Marshal returns the file in the structured format.
+func (f *File) Marshal() []byte {
+	b, _ := json.MarshalIndent(f, "", "  ")
+	return append(b, '\n')
+}
+
+// Accepted returns the accepted answers by part, from part one to the last
+// part with one, empty for parts in between without.
+func (f *File) Accepted() []string {
+	var answers []string
+	for _, p := range f.Parts {
+		if p.Accepted == "" {
+			continue
+		}
+		for len(answers) < p.Part {
+			answers = append(answers, "")
+		}
+		answers[p.Part-1] = p.Accepted
+	}
+
+	return answers
+}
+
+// Guesses returns every answer given: the accepted answers by part, as
+// Accepted does, followed by the rejected guesses of each part.
+func (f *File) Guesses() []string {
+	guesses := f.Accepted()
+	for _, p := range f.Parts {
+		for _, g := range p.Rejected {
+			guesses = append(guesses, g.Answer)
+		}
+	}
+
+	return guesses
+}
+
+// Part returns what is known about a part, added to the file when nothing
+// is.
+func (f *File) Part(part int) *Part {
+	for i := range f.Parts {
+		if f.Parts[i].Part == part {
+			return &f.Parts[i]
+		}
+	}
+
+	f.Parts = append(f.Parts, Part{Part: part})
+	return &f.Parts[len(f.Parts)-1]
+}
+
+// Reject records a wrong answer with its feedback, TooHigh, TooLow or empty.
+func (p *Part) Reject(answer, feedback string) {
+	for i, g := range p.Rejected {
+		if g.Answer == answer {
+			p.Rejected[i].Feedback = feedback
+			return
+		}
+	}
+
+	p.Rejected = append(p.Rejected, Guess{Answer: answer, Feedback: feedback})
+}
+
+// CheckGuess returns why an answer can't be right: it was rejected before,
+// another answer was accepted, or it isn't below every answer that was too
+// high and above every answer that was too low.
+func (p *Part) CheckGuess(answer string) error {
+	if p.Accepted != "" {
+		if p.Accepted == answer {
+			return nil
+		}
+		return fmt.Errorf("part %d was already accepted as %s", p.Part, p.Accepted)
+	}
+
+	n, numeric := parseNumber(answer)
+	for _, g := range p.Rejected {
+		if g.Answer == answer {
+			return fmt.Errorf("%s was already rejected for part %d", answer, p.Part)
+		}
+
+		bound, ok := parseNumber(g.Answer)
+		if !numeric || !ok {
+			continue
+		}
+		switch {
+		case g.Feedback == TooHigh && n >= bound:
+			return fmt.Errorf("%s is not below %s, which was too high", answer, g.Answer)
+		case g.Feedback == TooLow && n <= bound:
+			return fmt.Errorf("%s is not above %s, which was too low", answer, g.Answer)
+		}
+	}
+
+	return nil
+}
+
+func parseNumber(s string) (int64, bool) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	return n, err == nil
+}
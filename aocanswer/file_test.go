@@ -0,0 +1,78 @@
+package aocanswer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	plain, err := Parse([]byte("1052\n\n  6295 \n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(plain.Accepted(), ","); got != "1052,6295" {
+		t.Errorf("unexpected plain answers %s", got)
+	}
+
+	structured, err := Parse(plain.Marshal())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(structured.Accepted(), ","); got != "1052,6295" {
+		t.Errorf("expected the plain answers to survive a round trip, got %s", got)
+	}
+
+	// part two accepted before part one, which only has wrong guesses
+	f, err := Parse([]byte(`{"parts": [{"part": 2, "accepted": "6295"}, {"part": 1, "rejected": [{"answer": "1100", "feedback": "too high"}]}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(f.Accepted(), ","); got != ",6295" {
+		t.Errorf("expected an empty part one, got %q", got)
+	}
+	if got := strings.Join(f.Guesses(), ","); got != ",6295,1100" {
+		t.Errorf("expected the rejected guess after the accepted answers, got %q", got)
+	}
+
+	if _, err := Parse([]byte(`{"parts": [{"part": 0}]}`)); err == nil {
+		t.Errorf("expected part 0 to be an error")
+	}
+	if empty, err := Parse(nil); err != nil || len(empty.Accepted()) != 0 {
+		t.Errorf("expected an empty file to have no answers, got %v, %v", empty, err)
+	}
+}
+
+func TestCheckGuess(t *testing.T) {
+	f := &File{}
+	p := f.Part(1)
+	p.Reject("1100", TooHigh)
+	p.Reject("900", TooLow)
+	p.Reject("1000", "")
+	p.Reject("1100", TooHigh)
+	if len(p.Rejected) != 3 {
+		t.Errorf("expected a guess to be recorded once, got %+v", p.Rejected)
+	}
+
+	for guess, ok := range map[string]bool{
+		"950":  true,
+		"1099": true,
+		"1100": false,
+		"1200": false,
+		"900":  false,
+		"850":  false,
+		"1000": false,
+		"abc":  true,
+	} {
+		if err := p.CheckGuess(guess); (err == nil) != ok {
+			t.Errorf("guess %s: expected ok %v, got %v", guess, ok, err)
+		}
+	}
+
+	p.Accepted = "950"
+	if err := p.CheckGuess("951"); err == nil {
+		t.Errorf("expected a guess other than the accepted answer to be refused")
+	}
+	if f.Part(1) != p || len(f.Parts) != 1 {
+		t.Errorf("expected Part to return the part already in the file")
+	}
+}
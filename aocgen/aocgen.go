@@ -0,0 +1,148 @@
+// Package aocgen generates random valid inputs for each day's puzzle, so
+// solutions can be tested against each other without real inputs.
+//
+// Size is a number of items, rotations, ranges, banks, grid rows or
+// problems, and is how inputs are scaled up far beyond the real ones for
+// benchmarks. Inputs are the same for the same day, size and seed.
+//
+// A generated input is kept as the items it is made of, rotations, ranges or
+// grid rows, so it can be shrunk while it stays valid: an input two solutions
+// disagree on is cut down to the few items they still disagree on.
+package aocgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+)
+
+// Generator generates the inputs of a day.
+type Generator interface {
+	// Generate returns an input of about size items, the same for the same
+	// random source
+	Generate(r *rand.Rand, size int) Input
+	// RealSize is about the size of the real inputs
+	RealSize() int
+}
+
+// Input is a generated input.
+type Input interface {
+	Bytes() []byte
+	// Shrink returns smaller valid inputs, the biggest cuts first
+	Shrink() []Input
+}
+
+// Items is an input made of items, joined by Format.
+type Items[T any] struct {
+	Items  []T
+	Format func(items []T) []byte
+	// ShrinkItem returns smaller versions of an item, nil when there are none
+	ShrinkItem func(item T) []T
+	// Valid tells if items still make a valid input, any non empty set of
+	// items does when nil
+	Valid func(items []T) bool
+}
+
+// Bytes formats the items.
+func (in Items[T]) Bytes() []byte {
+	return in.Format(in.Items)
+}
+
+// Shrink drops halves, then quarters and so on down to single items, then
+// shrinks items one at a time.
+func (in Items[T]) Shrink() []Input {
+	var smaller []Input
+	add := func(items []T) {
+		if len(items) == 0 || (in.Valid != nil && !in.Valid(items)) {
+			return
+		}
+		shrunk := in
+		shrunk.Items = items
+		smaller = append(smaller, shrunk)
+	}
+
+	for chunk := len(in.Items) / 2; chunk >= 1; chunk /= 2 {
+		for start := 0; start < len(in.Items); start += chunk {
+			end := min(start+chunk, len(in.Items))
+			items := append(append([]T(nil), in.Items[:start]...), in.Items[end:]...)
+			add(items)
+		}
+	}
+
+	if in.ShrinkItem == nil {
+		return smaller
+	}
+	for i, item := range in.Items {
+		for _, s := range in.ShrinkItem(item) {
+			items := append([]T(nil), in.Items...)
+			items[i] = s
+			add(items)
+		}
+	}
+
+	return smaller
+}
+
+var generators = map[int]Generator{}
+
+// Register makes a day's generator available.
+func Register(day int, g Generator) {
+	generators[day] = g
+}
+
+// Lookup returns the generator of a day.
+func Lookup(day int) (Generator, bool) {
+	g, ok := generators[day]
+	return g, ok
+}
+
+// Days returns the days with a generator.
+func Days() []int {
+	var days []int
+	for day := range generators {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+
+	return days
+}
+
+// Generate returns the input of a day's generator for a size and seed.
+func Generate(day, size int, seed uint64) ([]byte, error) {
+	g, ok := Lookup(day)
+	if !ok {
+		return nil, fmt.Errorf("no input generator for day %d", day)
+	}
+	if size < 1 {
+		return nil, fmt.Errorf("size must be at least 1, got %d", size)
+	}
+
+	return g.Generate(NewRand(seed), size).Bytes(), nil
+}
+
+// NewRand returns the random source of a seed.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// digits returns a number of n digits, none of them 0 when noZero is set.
+func digits(r *rand.Rand, n int, noZero bool) int {
+	number := 0
+	for i := range n {
+		d := r.IntN(10)
+		if noZero || i == 0 {
+			d = 1 + r.IntN(9)
+		}
+		number = number*10 + d
+	}
+
+	return number
+}
+
+// dropDigit returns n without its last digit, nil when that leaves nothing.
+func dropDigit(n int) []int {
+	if n < 10 {
+		return nil
+	}
+	return []int{n / 10}
+}
@@ -0,0 +1,110 @@
+package aocgen
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, day := range Days() {
+		g, _ := Lookup(day)
+
+		a := g.Generate(NewRand(42), 8).Bytes()
+		b := g.Generate(NewRand(42), 8).Bytes()
+		if !bytes.Equal(a, b) {
+			t.Errorf("day %d: expected the same input for the same seed, got:\n%s\nand:\n%s", day, a, b)
+		}
+		if len(a) == 0 || a[len(a)-1] != '\n' {
+			t.Errorf("day %d: expected a newline terminated input, got %q", day, a)
+		}
+	}
+}
+
+func TestShrink(t *testing.T) {
+	in := Items[int]{
+		Items: []int{1, 20, 3, 4},
+		Format: func(items []int) []byte {
+			var s []string
+			for _, item := range items {
+				s = append(s, strconv.Itoa(item))
+			}
+			return []byte(strings.Join(s, ","))
+		},
+		ShrinkItem: func(item int) []int {
+			if item < 10 {
+				return nil
+			}
+			return []int{item / 10}
+		},
+		Valid: func(items []int) bool {
+			return len(items) >= 2
+		},
+	}
+
+	var got []string
+	for _, smaller := range in.Shrink() {
+		got = append(got, string(smaller.Bytes()))
+	}
+
+	// halves, then single items, then 20 down to 2
+	expected := []string{"3,4", "1,20", "20,3,4", "1,3,4", "1,20,4", "1,20,3", "1,2,3,4"}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected shrinks.\n\nExpecting:\n%s\n\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestWorksheet(t *testing.T) {
+	got := string(formatWorksheet([]problem{
+		{Numbers: []int{123, 45, 6}, Multiply: true},
+		{Numbers: []int{64, 23, 314}, Left: true},
+	}))
+
+	expected := "123 64 \n 45 23 \n  6 314\n*   +  \n"
+	if got != expected {
+		t.Errorf("unexpected worksheet.\n\nExpecting:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestShrinkDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Input
+		expected []string
+	}{
+		{
+			// a single rotation can't be dropped, only turned less
+			name:     "rotations",
+			input:    withItems(rotations{}.Generate(NewRand(1), 1), []rotation{{Left: true, Distance: 250}}),
+			expected: []string{"L50\n", "L125\n", "L249\n"},
+		},
+		{
+			// the only range or the only ID can't be dropped, the range
+			// is halved
+			name:     "ingredients",
+			input:    withItems(ingredients{}.Generate(NewRand(1), 2), []ingredient{{Range: true, Lo: 10, Hi: 13}, {Lo: 12}}),
+			expected: []string{"10-11\n\n12\n", "12-13\n\n12\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, smaller := range tt.input.Shrink() {
+				got = append(got, string(smaller.Bytes()))
+			}
+
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("unexpected shrinks %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// withItems returns a generated input with other items.
+func withItems[T any](in Input, items []T) Input {
+	generated := in.(Items[T])
+	generated.Items = items
+	return generated
+}
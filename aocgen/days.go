@@ -0,0 +1,322 @@
+package aocgen
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(1, rotations{})
+	Register(2, idRanges{})
+	Register(3, banks{})
+	Register(4, rolls{})
+	Register(5, ingredients{})
+	Register(6, worksheet{})
+}
+
+// rotations are day 1's L and R turns of the dial, size of them.
+type rotations struct{}
+
+type rotation struct {
+	Left     bool
+	Distance int
+}
+
+func (rotations) RealSize() int { return 4000 }
+
+func (rotations) Generate(r *rand.Rand, size int) Input {
+	items := make([]rotation, size)
+	for i := range items {
+		// mostly short turns, some of several full turns
+		distance := 1 + r.IntN(99)
+		if r.IntN(4) == 0 {
+			distance = 1 + r.IntN(999)
+		}
+		items[i] = rotation{Left: r.IntN(2) == 0, Distance: distance}
+	}
+
+	return Items[rotation]{
+		Items: items,
+		Format: func(items []rotation) []byte {
+			var b bytes.Buffer
+			for _, item := range items {
+				direction := "R"
+				if item.Left {
+					direction = "L"
+				}
+				fmt.Fprintf(&b, "%s%d\n", direction, item.Distance)
+			}
+			return b.Bytes()
+		},
+		ShrinkItem: func(item rotation) []rotation {
+			var smaller []rotation
+			for _, d := range []int{item.Distance % 100, item.Distance / 2, item.Distance - 1} {
+				if d > 0 && d < item.Distance && (len(smaller) == 0 || smaller[len(smaller)-1].Distance != d) {
+					smaller = append(smaller, rotation{item.Left, d})
+				}
+			}
+			return smaller
+		},
+	}
+}
+
+// idRanges are day 2's comma separated product ID ranges, on one line, size
+// of them.
+type idRanges struct{}
+
+type idRange struct {
+	Lo, Hi int
+}
+
+func (idRanges) RealSize() int { return 35 }
+
+func (idRanges) Generate(r *rand.Rand, size int) Input {
+	// ranges are laid out in order with gaps of any length, so they don't
+	// overlap, then shuffled
+	items := make([]idRange, size)
+	next := 1
+	for i := range items {
+		lo := next + digits(r, 1+r.IntN(9), false) - 1
+		if r.IntN(3) == 0 {
+			// just below a power of ten, so the range spans two lengths
+			lo = max(pow10(len(strconv.Itoa(lo)))-1-r.IntN(100), next)
+		}
+		items[i] = idRange{lo, lo + r.IntN(1000)}
+		next = items[i].Hi + 1
+	}
+	r.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+
+	return Items[idRange]{
+		Items: items,
+		Format: func(items []idRange) []byte {
+			ranges := make([]string, len(items))
+			for i, item := range items {
+				ranges[i] = fmt.Sprintf("%d-%d", item.Lo, item.Hi)
+			}
+			return []byte(strings.Join(ranges, ",") + "\n")
+		},
+		ShrinkItem: halves,
+	}
+}
+
+// banks are day 3's lines of battery joltages, at least 12 digits each, size
+// of them.
+type banks struct{}
+
+func (banks) RealSize() int { return 200 }
+
+func (banks) Generate(r *rand.Rand, size int) Input {
+	length := 12 + r.IntN(30)
+	items := make([]string, size)
+	for i := range items {
+		var b strings.Builder
+		for range length {
+			b.WriteByte(byte('1' + r.IntN(9)))
+		}
+		items[i] = b.String()
+	}
+
+	return Items[string]{
+		Items:  items,
+		Format: lines,
+		ShrinkItem: func(bank string) []string {
+			if len(bank) <= 12 {
+				return nil
+			}
+			return []string{bank[1:], bank[:len(bank)-1]}
+		},
+	}
+}
+
+// rolls are day 4's grid of paper rolls, @, and empty spaces, size by size
+// with a row per item.
+type rolls struct{}
+
+func (rolls) RealSize() int { return 140 }
+
+func (rolls) Generate(r *rand.Rand, size int) Input {
+	density := 0.4 + 0.4*r.Float64()
+	items := make([]string, size)
+	for i := range items {
+		row := bytes.Repeat([]byte("."), size)
+		for x := range row {
+			if r.Float64() < density {
+				row[x] = '@'
+			}
+		}
+		items[i] = string(row)
+	}
+
+	return Items[string]{Items: items, Format: lines}
+}
+
+// ingredients are day 5's fresh ID ranges, a blank line and the available
+// IDs, size of them all together.
+type ingredients struct{}
+
+type ingredient struct {
+	// Range tells a fresh range, Lo-Hi, from an available ID, Lo
+	Range  bool
+	Lo, Hi int
+}
+
+func (ingredients) RealSize() int { return 1200 }
+
+func (ingredients) Generate(r *rand.Rand, size int) Input {
+	// ranges are around a few points so they overlap, nest and touch
+	var centers []int
+	for range 1 + size/4 {
+		centers = append(centers, digits(r, 1+r.IntN(14), false))
+	}
+	near := func() int {
+		return max(centers[r.IntN(len(centers))]+r.IntN(41)-20, 1)
+	}
+
+	var items []ingredient
+	for range max(size/2, 1) {
+		lo := near()
+		items = append(items, ingredient{Range: true, Lo: lo, Hi: lo + r.IntN(30)})
+	}
+	for range max(size-size/2, 1) {
+		items = append(items, ingredient{Lo: near()})
+	}
+
+	return Items[ingredient]{
+		Items: items,
+		Format: func(items []ingredient) []byte {
+			var ranges, ids bytes.Buffer
+			for _, item := range items {
+				if item.Range {
+					fmt.Fprintf(&ranges, "%d-%d\n", item.Lo, item.Hi)
+				} else {
+					fmt.Fprintf(&ids, "%d\n", item.Lo)
+				}
+			}
+			return append(append(ranges.Bytes(), '\n'), ids.Bytes()...)
+		},
+		ShrinkItem: func(item ingredient) []ingredient {
+			if !item.Range {
+				return nil
+			}
+			var smaller []ingredient
+			for _, h := range halves(idRange{item.Lo, item.Hi}) {
+				smaller = append(smaller, ingredient{Range: true, Lo: h.Lo, Hi: h.Hi})
+			}
+			return smaller
+		},
+		Valid: func(items []ingredient) bool {
+			ranges, ids := 0, 0
+			for _, item := range items {
+				if item.Range {
+					ranges++
+				} else {
+					ids++
+				}
+			}
+			return ranges > 0 && ids > 0
+		},
+	}
+}
+
+// worksheet is day 6's problems, size of them, a column of numbers each with
+// its operator below, numbers lined up left or right within their column.
+type worksheet struct{}
+
+type problem struct {
+	Numbers  []int
+	Multiply bool
+	Left     bool
+}
+
+func (worksheet) RealSize() int { return 1000 }
+
+func (worksheet) Generate(r *rand.Rand, size int) Input {
+	rows := 3 + r.IntN(2)
+	items := make([]problem, size)
+	for i := range items {
+		numbers := make([]int, rows)
+		for j := range numbers {
+			numbers[j] = digits(r, 1+r.IntN(4), true)
+		}
+		items[i] = problem{Numbers: numbers, Multiply: r.IntN(2) == 0, Left: r.IntN(2) == 0}
+	}
+
+	return Items[problem]{
+		Items:  items,
+		Format: formatWorksheet,
+		ShrinkItem: func(p problem) []problem {
+			var smaller []problem
+			for i, n := range p.Numbers {
+				for _, d := range dropDigit(n) {
+					numbers := append([]int(nil), p.Numbers...)
+					numbers[i] = d
+					smaller = append(smaller, problem{numbers, p.Multiply, p.Left})
+				}
+			}
+			return smaller
+		},
+	}
+}
+
+// formatWorksheet lays the problems out side by side, a blank column apart,
+// with every line as long as the others.
+func formatWorksheet(problems []problem) []byte {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	lines := make([][]string, len(problems[0].Numbers)+1)
+	for _, p := range problems {
+		width := 0
+		for _, n := range p.Numbers {
+			width = max(width, len(strconv.Itoa(n)))
+		}
+
+		format := fmt.Sprintf("%%%dd", width)
+		if p.Left {
+			format = fmt.Sprintf("%%-%dd", width)
+		}
+		for i, n := range p.Numbers {
+			lines[i] = append(lines[i], fmt.Sprintf(format, n))
+		}
+
+		operator := "+"
+		if p.Multiply {
+			operator = "*"
+		}
+		lines[len(lines)-1] = append(lines[len(lines)-1], fmt.Sprintf("%-*s", width, operator))
+	}
+
+	var b bytes.Buffer
+	for _, line := range lines {
+		b.WriteString(strings.Join(line, " ") + "\n")
+	}
+
+	return b.Bytes()
+}
+
+func lines(items []string) []byte {
+	return []byte(strings.Join(items, "\n") + "\n")
+}
+
+// halves splits a range in two.
+func halves(item idRange) []idRange {
+	if item.Hi <= item.Lo {
+		return nil
+	}
+	mid := item.Lo + (item.Hi-item.Lo)/2
+	return []idRange{{item.Lo, mid}, {mid + 1, item.Hi}}
+}
+
+func pow10(n int) int {
+	p := 1
+	for range n {
+		p *= 10
+	}
+	return p
+}
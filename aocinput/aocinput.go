@@ -0,0 +1,172 @@
+// Package aocinput loads the puzzle input of a day and splits it up, so
+// solutions don't each open "../input" and scan it their own way.
+//
+// The input is the first of:
+//   - the file named by the -input flag, stdin for "-", when the command
+//     added it with RegisterFlags
+//   - the file named by the AOC_INPUT environment variable
+//   - the day directory's input file, ../input from dayNN/ai and dayNN/human
+//   - stdin, when it is a pipe or a file
+//
+// Stdin comes last as an idle pipe, as some shells and CI runners leave it,
+// would block forever.
+//
+// CRLF line endings become LF and trailing newlines are dropped, trailing
+// spaces are kept as some puzzles line columns up with them.
+package aocinput
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EnvVar names the environment variable an input file can be given in.
+const EnvVar = "AOC_INPUT"
+
+// path is set by the -input flag of RegisterFlags.
+var path string
+
+var (
+	blankLinesRegex = regexp.MustCompile(`\n{2,}`)
+	// a dash is only a sign at the start or after something that isn't a
+	// digit, so 11-22 is a range
+	intRegex = regexp.MustCompile(`(?:^|\D)(-?\d+)`)
+)
+
+// RegisterFlags adds the -input flag to fs. The command parses it, Load and
+// LoadDay only read it.
+func RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&path, "input", "", "puzzle input file, instead of the day's input, - for stdin")
+}
+
+// Load returns the normalized input.
+func Load() ([]byte, error) {
+	return load(true, "../input", "input")
+}
+
+// LoadDay returns the normalized input, with dayDir/input as the day
+// directory's input file, for running solutions from elsewhere. Stdin is
+// only read for -input -, as there is no telling which day it is for.
+func LoadDay(dayDir string) ([]byte, error) {
+	return load(false, filepath.Join(dayDir, "input"))
+}
+
+func load(stdin bool, dayInputs ...string) ([]byte, error) {
+	switch {
+	case path == "-":
+		return readStdin()
+	case path != "":
+		return readFile(path)
+	}
+
+	if env := os.Getenv(EnvVar); env != "" {
+		return readFile(env)
+	}
+
+	for _, name := range dayInputs {
+		if _, err := os.Stat(name); err == nil {
+			return readFile(name)
+		}
+	}
+
+	if info, err := os.Stdin.Stat(); stdin && err == nil && info.Mode()&os.ModeCharDevice == 0 {
+		return readStdin()
+	}
+
+	return nil, fmt.Errorf("no input: pass -input, set %s, run from dayNN/ai or dayNN/human or pipe it in", EnvVar)
+}
+
+func readStdin() ([]byte, error) {
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+
+	return Normalize(b), nil
+}
+
+// MustLoad returns the normalized input and exits when there is none.
+func MustLoad() []byte {
+	input, err := Load()
+	if err != nil {
+		log.Fatalf("aocinput: %v", err)
+	}
+
+	return input
+}
+
+func readFile(name string) ([]byte, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return Normalize(b), nil
+}
+
+// Normalize turns CRLF into LF and drops trailing newlines.
+func Normalize(b []byte) []byte {
+	b = bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))
+	return bytes.TrimRight(b, "\n")
+}
+
+// NewScanner returns a line scanner without bufio.Scanner's 64KB limit on
+// the length of a line.
+func NewScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, math.MaxInt)
+	return scanner
+}
+
+// Lines splits the input into lines.
+func Lines[T ~string | ~[]byte](input T) []string {
+	if len(input) == 0 {
+		return nil
+	}
+
+	return strings.Split(string(input), "\n")
+}
+
+// Grid splits the input into rows of bytes.
+func Grid[T ~string | ~[]byte](input T) [][]byte {
+	var grid [][]byte
+	for _, line := range Lines(input) {
+		grid = append(grid, []byte(line))
+	}
+
+	return grid
+}
+
+// Blocks splits the input on blank lines.
+func Blocks[T ~string | ~[]byte](input T) []string {
+	if len(input) == 0 {
+		return nil
+	}
+
+	return blankLinesRegex.Split(string(input), -1)
+}
+
+// Ints returns every integer in the input, with its sign, wherever it is. A
+// dash between digits separates them, so 11-22 is 11 and 22.
+func Ints[T ~string | ~[]byte](input T) ([]int, error) {
+	var ints []int
+	for _, m := range intRegex.FindAllStringSubmatch(string(input), -1) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, n)
+	}
+
+	return ints, nil
+}
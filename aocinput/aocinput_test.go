@@ -0,0 +1,103 @@
+package aocinput
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	got := string(Normalize([]byte("*   +  \r\n1 2\r\n\r\n")))
+	if got != "*   +  \n1 2" {
+		t.Errorf("unexpected normalized input %q", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "input"), []byte("day dir\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	other := filepath.Join(dir, "other")
+	if err := os.WriteFile(other, []byte("env\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	solverDir := filepath.Join(dir, "human")
+	if err := os.Mkdir(solverDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(solverDir)
+
+	t.Setenv(EnvVar, "")
+	if got, err := Load(); err != nil || string(got) != "day dir" {
+		t.Errorf("expected the day directory's input, got %q, %v", got, err)
+	}
+
+	t.Setenv(EnvVar, other)
+	if got, err := Load(); err != nil || string(got) != "env" {
+		t.Errorf("expected the %s input, got %q, %v", EnvVar, got, err)
+	}
+
+	// -input only counts once a command registers and parses it
+	flags := flag.NewFlagSet("solution", flag.ContinueOnError)
+	RegisterFlags(flags)
+	t.Cleanup(func() { path = "" })
+	if err := flags.Parse([]string{"-input", filepath.Join(dir, "input")}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Load(); err != nil || string(got) != "day dir" {
+		t.Errorf("expected the -input input, got %q, %v", got, err)
+	}
+}
+
+func TestNewScanner(t *testing.T) {
+	long := strings.Repeat("1-2,", 100_000)
+	scanner := NewScanner(strings.NewReader(long + "\nshort"))
+
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lines) != 2 || lines[0] != long {
+		t.Errorf("expected the long line to be read whole, got %d lines", len(lines))
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	input := []byte("3-5\n10-14\n\n\n1\n-5")
+
+	if got := Lines(input); !reflect.DeepEqual(got, []string{"3-5", "10-14", "", "", "1", "-5"}) {
+		t.Errorf("unexpected lines %q", got)
+	}
+	if got := Lines(""); got != nil {
+		t.Errorf("expected no lines for an empty input, got %q", got)
+	}
+
+	if got := Blocks(input); !reflect.DeepEqual(got, []string{"3-5\n10-14", "1\n-5"}) {
+		t.Errorf("unexpected blocks %q", got)
+	}
+
+	if got := Grid(".@\n@."); !reflect.DeepEqual(got, [][]byte{[]byte(".@"), []byte("@.")}) {
+		t.Errorf("unexpected grid %q", got)
+	}
+
+	ints, err := Ints("x=12, y=-3 and 7")
+	if err != nil || !reflect.DeepEqual(ints, []int{12, -3, 7}) {
+		t.Errorf("unexpected ints %v, %v", ints, err)
+	}
+	// a dash between numbers is a range, not a sign
+	ints, err = Ints("-4\n11-22,95--115 a-3")
+	if err != nil || !reflect.DeepEqual(ints, []int{-4, 11, 22, 95, -115, -3}) {
+		t.Errorf("unexpected ints of ranges %v, %v", ints, err)
+	}
+	if _, err := Ints("99999999999999999999"); err == nil {
+		t.Errorf("expected an error for an integer out of range")
+	}
+}
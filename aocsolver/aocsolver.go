@@ -0,0 +1,195 @@
+// Package aocsolver is what every solution implements, so any of them can be
+// run in-process by day and variant, "ai" or "human", from one binary.
+//
+// The code of a solution lives in its importable dayNN/<variant>/puzzle
+// package, dayNN/<variant>/main.go only hands it the input with Main. The
+// solvers package registers them all.
+package aocsolver
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/IanShearer/aoc/aocinput"
+)
+
+// Answer is the answer to a part as it is typed into the website.
+type Answer string
+
+// Integer is any integer type an answer can be.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Int returns a numeric answer.
+func Int[T Integer](n T) Answer {
+	if n < 0 {
+		return Answer(strconv.FormatInt(int64(n), 10))
+	}
+	return Answer(strconv.FormatUint(uint64(n), 10))
+}
+
+// Solver solves both parts of a day's puzzle.
+type Solver interface {
+	Solve(input []byte) (Answer, Answer, error)
+}
+
+// SolverFunc lets a function be a Solver.
+type SolverFunc func(input []byte) (Answer, Answer, error)
+
+// Solve calls f.
+func (f SolverFunc) Solve(input []byte) (Answer, Answer, error) {
+	return f(input)
+}
+
+// PartSolver is a Solver whose parts can also be solved one at a time, so
+// each can be timed on its own.
+type PartSolver interface {
+	Solver
+	Part(part int, input []byte) (Answer, error)
+}
+
+// PartFunc solves one part of a puzzle.
+type PartFunc func(input []byte) (Answer, error)
+
+// IntPart makes a PartFunc of a part the way aoc create scaffolds them, a
+// func(input string) returning an integer.
+func IntPart[T Integer](part func(input string) T) PartFunc {
+	return func(input []byte) (Answer, error) {
+		return Int(part(string(input))), nil
+	}
+}
+
+// SolveParts answers both parts of the input with a function per part.
+func SolveParts(input []byte, partOne, partTwo PartFunc) (Answer, Answer, error) {
+	one, err := partOne(input)
+	if err != nil {
+		return "", "", err
+	}
+
+	two, err := partTwo(input)
+	if err != nil {
+		return "", "", err
+	}
+
+	return one, two, nil
+}
+
+// WithParts adds the PartOne and PartTwo functions of a puzzle package to
+// the Solver of its Solve.
+func WithParts(s Solver, partOne, partTwo PartFunc) PartSolver {
+	return withParts{s, [2]PartFunc{partOne, partTwo}}
+}
+
+type withParts struct {
+	Solver
+	parts [2]PartFunc
+}
+
+// Part solves part 1 or 2 of the input.
+func (s withParts) Part(part int, input []byte) (Answer, error) {
+	if part < 1 || part > len(s.parts) {
+		return "", fmt.Errorf("aocsolver: no part %d", part)
+	}
+	return s.parts[part-1](input)
+}
+
+type key struct {
+	day     int
+	variant string
+}
+
+var registry = map[key]Solver{}
+
+// Register makes a solver available by day and variant.
+func Register(day int, variant string, s Solver) {
+	k := key{day, variant}
+	if _, ok := registry[k]; ok {
+		panic(fmt.Sprintf("aocsolver: day %d %s registered twice", day, variant))
+	}
+
+	registry[k] = s
+}
+
+// Lookup returns the solver of a day's variant.
+func Lookup(day int, variant string) (Solver, bool) {
+	s, ok := registry[key{day, variant}]
+	return s, ok
+}
+
+// Days returns the days with a registered solver.
+func Days() []int {
+	seen := make(map[int]bool)
+	var days []int
+	for k := range registry {
+		if !seen[k.day] {
+			seen[k.day] = true
+			days = append(days, k.day)
+		}
+	}
+	sort.Ints(days)
+
+	return days
+}
+
+// Variants returns the registered variants of a day.
+func Variants(day int) []string {
+	var variants []string
+	for k := range registry {
+		if k.day == day {
+			variants = append(variants, k.variant)
+		}
+	}
+	sort.Strings(variants)
+
+	return variants
+}
+
+// SolveEach answers the parts of the input, calling answer with each as soon
+// as it has it. A PartSolver answers part one before it starts on part two,
+// other solvers answer both at once.
+func SolveEach(s Solver, input []byte, answer func(part int, a Answer)) error {
+	parts, ok := s.(PartSolver)
+	if !ok {
+		partOne, partTwo, err := s.Solve(input)
+		if err != nil {
+			return err
+		}
+		answer(1, partOne)
+		answer(2, partTwo)
+		return nil
+	}
+
+	for part := 1; part <= 2; part++ {
+		a, err := parts.Part(part, input)
+		if err != nil {
+			return err
+		}
+		answer(part, a)
+	}
+
+	return nil
+}
+
+// PartNames are the parts as aoc run reads them, "Part One: X".
+var PartNames = [2]string{"One", "Two"}
+
+// Main solves the input found by aocinput, with the -input flag, and prints
+// the answers the way aoc run reads them, each as soon as it is solved. It
+// is the whole of a solution's main.
+func Main(s Solver) {
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	aocinput.RegisterFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	err := SolveEach(s, aocinput.MustLoad(), func(part int, a Answer) {
+		fmt.Printf("Part %s: %s\n", PartNames[part-1], a)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,33 @@
+package aocsolver
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSolveEach(t *testing.T) {
+	var events []string
+	part := func(name string, a Answer) PartFunc {
+		return func(input []byte) (Answer, error) {
+			events = append(events, "solve "+name)
+			return a, nil
+		}
+	}
+	solve := SolverFunc(func(input []byte) (Answer, Answer, error) {
+		t.Fatal("Solve called on a PartSolver")
+		return "", "", nil
+	})
+
+	s := WithParts(solve, part("one", "1"), part("two", "2"))
+	err := SolveEach(s, nil, func(part int, a Answer) {
+		events = append(events, "answer "+PartNames[part-1]+" "+string(a))
+	})
+	if err != nil {
+		t.Fatalf("SolveEach() error = %v", err)
+	}
+
+	want := []string{"solve one", "answer One 1", "solve two", "answer Two 2"}
+	if !slices.Equal(events, want) {
+		t.Errorf("SolveEach() events = %q, want %q", events, want)
+	}
+}
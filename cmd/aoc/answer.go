@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/IanShearer/aoc/aocanswer"
+)
+
+func answerDay() {
+	flags := flag.NewFlagSet("answer", flag.ExitOnError)
+	accepted := flags.Bool("accepted", false, "record the answer as accepted")
+	tooHigh := flags.Bool("too-high", false, "record the answer as rejected for being too high")
+	tooLow := flags.Bool("too-low", false, "record the answer as rejected for being too low")
+	wrong := flags.Bool("wrong", false, "record the answer as rejected without a hint")
+	args := parseFlags(flags, os.Args[2:])
+
+	if len(args) < 3 {
+		fmt.Fprintf(os.Stderr, "Usage: aoc answer [--accepted|--too-high|--too-low|--wrong] <day_number> <part> <answer>\n")
+		fmt.Fprintf(os.Stderr, "Example: aoc answer 5 1 1100 --too-high\n")
+		os.Exit(1)
+	}
+
+	dayNum, err := strconv.Atoi(args[0])
+	if err != nil || dayNum < 1 || dayNum > 25 {
+		fmt.Fprintf(os.Stderr, "Error: day number must be between 1 and 25\n")
+		os.Exit(1)
+	}
+
+	part, err := strconv.Atoi(args[1])
+	if err != nil || part < 1 || part > 2 {
+		fmt.Fprintf(os.Stderr, "Error: part must be 1 or 2\n")
+		os.Exit(1)
+	}
+	answer := args[2]
+
+	records := 0
+	for _, set := range []bool{*accepted, *tooHigh, *tooLow, *wrong} {
+		if set {
+			records++
+		}
+	}
+	if records > 1 {
+		fmt.Fprintf(os.Stderr, "Error: --accepted, --too-high, --too-low and --wrong don't go together\n")
+		os.Exit(1)
+	}
+
+	path := answersPath(dayNum)
+	file, err := aocanswer.ReadFile(path)
+	if os.IsNotExist(err) {
+		file, err = &aocanswer.File{}, nil
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading answers: %v\n", err)
+		os.Exit(1)
+	}
+	p := file.Part(part)
+
+	// Without anything to record, tell if the answer is worth submitting
+	if records == 0 {
+		if err := p.CheckGuess(answer); err != nil {
+			fmt.Printf("Don't submit: %v\n", err)
+			os.Exit(1)
+		}
+		if p.Accepted == answer {
+			fmt.Printf("%s is the accepted answer of day %d part %d\n", answer, dayNum, part)
+			return
+		}
+		fmt.Printf("%s is within what is known about day %d part %d\n", answer, dayNum, part)
+		return
+	}
+
+	switch {
+	case *accepted:
+		p.Accepted = answer
+	case *tooHigh:
+		p.Reject(answer, aocanswer.TooHigh)
+	case *tooLow:
+		p.Reject(answer, aocanswer.TooLow)
+	default:
+		p.Reject(answer, "")
+	}
+
+	if err := os.WriteFile(path, file.Marshal(), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing answers: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Successfully recorded day %d part %d in %s\n", dayNum, part, filepath.ToSlash(path))
+}
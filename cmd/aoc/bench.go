@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IanShearer/aoc/aocgen"
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+func benchDays() {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	runs := flags.Int("runs", 20, "number of times each solver is run")
+	threshold := flags.Float64("threshold", 10, "percentage the median may grow by before it counts as a regression")
+	historyPath := flags.String("history", internal.BenchHistoryFile, "file results are appended to, nothing is written when empty")
+	size := flags.Int("size", 0, "benchmark on a generated input of this size instead of the day's input, see aoc gen")
+	seed := flags.Uint64("seed", 1, "seed of the generated input")
+	args := parseFlags(flags, os.Args[2:])
+
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: aoc bench [--runs 20] [--threshold 10] [--history %s] [--size N [--seed N]] <day_number|all>\n", internal.BenchHistoryFile)
+		fmt.Fprintf(os.Stderr, "Example: aoc bench 5\n")
+		os.Exit(1)
+	}
+
+	dayNums, err := parseDays(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	commit, dirty, err := internal.GitCommit()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var history []internal.BenchResult
+	if *historyPath != "" {
+		history, err = internal.ReadBenchHistory(*historyPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: reading bench history: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	tmp, err := os.MkdirTemp("", "aoc-bench-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Benchmarks run one at a time, they would skew each other otherwise
+	failed := false
+	var results []internal.BenchResult
+	for _, dayNum := range dayNums {
+		inputPath := ""
+		if *size > 0 {
+			input, err := aocgen.Generate(dayNum, *size, *seed)
+			if err == nil {
+				inputPath = filepath.Join(tmp, fmt.Sprintf("day%02d-input", dayNum))
+				err = os.WriteFile(inputPath, input, 0644)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				failed = true
+				continue
+			}
+		}
+
+		for _, name := range internal.SolverNames {
+			solver, err := internal.NewSolver(dayNum, name)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err == nil {
+				err = solver.Build()
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				failed = true
+				continue
+			}
+
+			fmt.Fprintf(os.Stderr, "Benchmarking %s...\n", solver)
+			result, err := solver.Bench(*runs, inputPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				failed = true
+				continue
+			}
+
+			result.Commit, result.Dirty, result.Date = commit, dirty, time.Now().UTC()
+			if *size > 0 {
+				result.Size, result.Seed = *size, *seed
+			}
+			results = append(results, result)
+		}
+	}
+
+	os.RemoveAll(tmp)
+
+	printBench(dayNums, results)
+
+	regressed := false
+	for _, result := range results {
+		previous, ok := internal.PreviousBench(history, result)
+		if !ok {
+			continue
+		}
+
+		if slowdown := result.Slowdown(previous); slowdown*100 > *threshold {
+			regressed = true
+			fmt.Printf("Regression: day%02d/%s median %v is %.0f%% slower than %v at %s\n",
+				result.Day, result.Solver, formatDuration(result.Median), slowdown*100, formatDuration(previous.Median), benchCommit(previous))
+		}
+	}
+
+	if *historyPath != "" && len(results) > 0 {
+		if err := internal.AppendBenchHistory(*historyPath, results); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: writing bench history: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Appended %d result(s) for %s to %s\n", len(results), benchCommit(results[0]), *historyPath)
+	}
+
+	if failed || regressed {
+		os.Exit(1)
+	}
+}
+
+// printBench prints the solvers of each day side by side, and which one is
+// faster.
+func printBench(dayNums []int, results []internal.BenchResult) {
+	byDay := make(map[int]map[string]internal.BenchResult)
+	for _, r := range results {
+		if byDay[r.Day] == nil {
+			byDay[r.Day] = make(map[string]internal.BenchResult)
+		}
+		byDay[r.Day][r.Solver] = r
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{"DAY"}
+	for _, name := range internal.SolverNames {
+		name = strings.ToUpper(name)
+		header = append(header, name+" MEDIAN", name+" PART 1", name+" PART 2", name+" P95", name+" ALLOCS", name+" BYTES")
+	}
+	fmt.Fprintln(w, strings.Join(append(header, "FASTER"), "\t"))
+
+	for _, dayNum := range dayNums {
+		solvers, ok := byDay[dayNum]
+		if !ok {
+			continue
+		}
+
+		row := []string{fmt.Sprintf("%02d", dayNum)}
+		for _, name := range internal.SolverNames {
+			r, ok := solvers[name]
+			if !ok {
+				row = append(row, "-", "-", "-", "-", "-", "-")
+				continue
+			}
+
+			row = append(row, formatDuration(r.Median).String(), formatPart(r.Parts[0]), formatPart(r.Parts[1]), formatDuration(r.P95).String())
+			if r.Method != internal.BenchInProcess {
+				row = append(row, "-", "-")
+			} else {
+				row = append(row, fmt.Sprint(r.Allocs), internal.FormatBytes(int64(r.Bytes)))
+			}
+		}
+		fmt.Fprintln(w, strings.Join(append(row, fasterSolver(solvers)), "\t"))
+	}
+	w.Flush()
+}
+
+// formatPart is the median of a part, or "-" when the solver couldn't time
+// it apart from the other.
+func formatPart(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return formatDuration(d).String()
+}
+
+// fasterSolver names the solver with the lowest median and by how much.
+func fasterSolver(solvers map[string]internal.BenchResult) string {
+	ai, okAI := solvers["ai"]
+	human, okHuman := solvers["human"]
+	if !okAI || !okHuman || ai.Median <= 0 || human.Median <= 0 {
+		return "-"
+	}
+
+	if ai.Median < human.Median {
+		return fmt.Sprintf("ai %.1fx", float64(human.Median)/float64(ai.Median))
+	}
+	return fmt.Sprintf("human %.1fx", float64(ai.Median)/float64(human.Median))
+}
+
+func benchCommit(r internal.BenchResult) string {
+	if r.Dirty {
+		return r.Commit + " (dirty)"
+	}
+	return r.Commit
+}
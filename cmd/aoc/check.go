@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/IanShearer/aoc/aocanswer"
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+// checkResult is one solver checked against its day's answers. Answers are
+// kept out of the JSON report, CI logs are public.
+type checkResult struct {
+	Day      int       `json:"day"`
+	Solver   string    `json:"solver"`
+	Language string    `json:"language,omitempty"`
+	Parts    [2]string `json:"parts"`
+	WallMS   float64   `json:"wall_ms"`
+	MaxRSS   int64     `json:"max_rss"`
+	Error    string    `json:"error,omitempty"`
+	answers  [2]string
+}
+
+// statusError is the status of both parts of a solver that didn't build or
+// run.
+const statusError = "error"
+
+type checkReport struct {
+	Results []checkResult  `json:"results"`
+	Totals  map[string]int `json:"totals"`
+}
+
+func checkDays() {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	jobs := flags.Int("jobs", runtime.NumCPU(), "number of solvers built and run at the same time")
+	jsonOutput := flags.Bool("json", false, "print a JSON report instead of the table, and don't offer to write answers")
+	args := parseFlags(flags, os.Args[2:])
+
+	daysArg := "all"
+	if len(args) > 0 {
+		daysArg = args[0]
+	}
+
+	dayNums, err := parseDays(daysArg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var solvers []*internal.Solver
+	for _, dayNum := range dayNums {
+		for _, name := range internal.SolverNames {
+			solver, err := internal.NewSolver(dayNum, name)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			solvers = append(solvers, solver)
+		}
+	}
+
+	answers := make(map[int][]string)
+	for _, dayNum := range dayNums {
+		dayAnswers, err := internal.ReadAcceptedAnswers(answersPath(dayNum))
+		if err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error: reading answers: %v\n", err)
+			os.Exit(1)
+		}
+		answers[dayNum] = dayAnswers
+	}
+
+	results := make([]checkResult, len(solvers))
+	sem := make(chan struct{}, max(*jobs, 1))
+	var wg sync.WaitGroup
+	for i, solver := range solvers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = checkSolver(solver, answers[solver.Day])
+		}()
+	}
+	wg.Wait()
+
+	report := checkReport{Results: results, Totals: map[string]int{}}
+	for _, r := range results {
+		for _, status := range r.Parts {
+			report.Totals[status]++
+		}
+	}
+
+	if *jsonOutput {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(b))
+	} else {
+		printCheck(dayNums, results)
+		offerAnswers(dayNums, answers, results)
+	}
+
+	if report.Totals[internal.StatusFail] > 0 || report.Totals[statusError] > 0 {
+		os.Exit(1)
+	}
+}
+
+func checkSolver(solver *internal.Solver, answers []string) checkResult {
+	r := checkResult{Day: solver.Day, Solver: solver.Name, Language: solver.Language.Name}
+
+	err := solver.Build()
+	var result *internal.RunResult
+	if err == nil {
+		result, err = solver.Run("")
+	}
+	if err != nil {
+		r.Error = err.Error()
+		r.Parts = [2]string{statusError, statusError}
+		return r
+	}
+
+	r.WallMS = float64(result.Wall.Microseconds()) / 1000
+	r.MaxRSS = result.MaxRSS
+	r.answers = result.Answers
+	for i := range r.Parts {
+		r.Parts[i] = internal.CheckAnswer(answers, i+1, result.Answers[i])
+	}
+
+	return r
+}
+
+// printCheck prints a row per day with a column per solver and part.
+func printCheck(dayNums []int, results []checkResult) {
+	byDay := make(map[int]map[string]checkResult)
+	for _, r := range results {
+		if byDay[r.Day] == nil {
+			byDay[r.Day] = make(map[string]checkResult)
+		}
+		byDay[r.Day][r.Solver] = r
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{"DAY"}
+	for _, name := range internal.SolverNames {
+		header = append(header, strings.ToUpper(name)+" P1", strings.ToUpper(name)+" P2")
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, dayNum := range dayNums {
+		row := []string{fmt.Sprintf("%02d", dayNum)}
+		for _, name := range internal.SolverNames {
+			r, ok := byDay[dayNum][name]
+			if !ok {
+				row = append(row, "-", "-")
+				continue
+			}
+			row = append(row, r.Parts[0], r.Parts[1])
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", r.Error)
+		}
+	}
+}
+
+// offerAnswers offers to fill in the answers files without accepted answers
+// of days where both solvers printed the same answers, unless they were
+// rejected before.
+func offerAnswers(dayNums []int, answers map[int][]string, results []checkResult) {
+	reader := bufio.NewReader(os.Stdin)
+	for _, dayNum := range dayNums {
+		if len(answers[dayNum]) > 0 {
+			continue
+		}
+
+		agreed := agreedAnswers(dayNum, results)
+		if len(agreed) == 0 {
+			continue
+		}
+
+		// keep the rejected guesses, and don't offer one of them
+		path := answersPath(dayNum)
+		file, err := aocanswer.ReadFile(path)
+		if os.IsNotExist(err) {
+			file, err = &aocanswer.File{}, nil
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading answers: %v\n", err)
+			os.Exit(1)
+		}
+
+		var refused error
+		for i, answer := range agreed {
+			if err := file.Part(i + 1).CheckGuess(answer); err != nil && refused == nil {
+				refused = err
+			}
+		}
+		if refused != nil {
+			fmt.Printf("Both day %d solvers answered %s, but %v\n", dayNum, strings.Join(agreed, " and "), refused)
+			continue
+		}
+
+		fmt.Printf("Both day %d solvers answered %s. Write them to %s? [y/N] ", dayNum, strings.Join(agreed, " and "), filepath.ToSlash(path))
+		reply, _ := reader.ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(reply)) != "y" {
+			continue
+		}
+
+		for i, answer := range agreed {
+			file.Part(i + 1).Accepted = answer
+		}
+		if err := os.WriteFile(path, file.Marshal(), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing answers: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Successfully wrote %s\n", filepath.ToSlash(path))
+	}
+}
+
+// agreedAnswers returns the answers, from part one on, every solver of a day
+// printed alike. There must be more than one solver to agree.
+func agreedAnswers(dayNum int, results []checkResult) []string {
+	var solvers []checkResult
+	for _, r := range results {
+		if r.Day == dayNum {
+			if r.Error != "" {
+				return nil
+			}
+			solvers = append(solvers, r)
+		}
+	}
+	if len(solvers) < 2 {
+		return nil
+	}
+
+	var agreed []string
+	for part := range 2 {
+		answer := solvers[0].answers[part]
+		for _, r := range solvers[1:] {
+			if r.answers[part] != answer {
+				answer = ""
+			}
+		}
+		if answer == "" {
+			break
+		}
+		agreed = append(agreed, answer)
+	}
+
+	return agreed
+}
+
+func answersPath(dayNum int) string {
+	return filepath.Join(fmt.Sprintf("day%02d", dayNum), "answers")
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,14 +10,25 @@ import (
 )
 
 func createDay() {
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: aoc create <day_number>")
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	force := flags.Bool("force", false, "overwrite files that already exist, the answers file is always kept")
+	langName := flags.String("lang", "go", fmt.Sprintf("language to scaffold the solutions in, one of %v", internal.LanguageNames()))
+	args := parseFlags(flags, os.Args[2:])
+
+	if len(args) < 1 {
+		fmt.Println("Usage: aoc create [--force] [--lang go|rust] <day_number>")
 		fmt.Println("Example: aoc create 5")
 		os.Exit(1)
 	}
 
+	lang, err := internal.LookupLanguage(*langName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	var dayNum int
-	_, err := fmt.Sscanf(os.Args[2], "%d", &dayNum)
+	_, err = fmt.Sscanf(args[0], "%d", &dayNum)
 	if err != nil || dayNum < 1 || dayNum > 25 {
 		fmt.Printf("Error: day number must be between 1 and 25\n")
 		os.Exit(1)
@@ -24,66 +36,201 @@ func createDay() {
 
 	// Format day with leading zero
 	dayDir := fmt.Sprintf("day%02d", dayNum)
+	tx := &internal.FileTransaction{Force: *force}
 
-	// Create main day directory
-	if err := os.Mkdir(dayDir, 0755); err != nil {
-		fmt.Printf("Error creating directory %s: %v\n", dayDir, err)
+	// Work out what is missing before going to the network, and go to the
+	// network before touching the filesystem
+	templateData := internal.TemplateData{Day: dayNum, Year: internal.Year}
+	if lang.Name == "go" {
+		// Go solutions import their puzzle package by module path
+		if templateData.Module, err = internal.ModulePath(); err != nil {
+			fmt.Printf("Error reading go.mod: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	files, err := renderSolutions(lang, templateData, dayDir)
+	if err != nil {
+		fmt.Printf("Error rendering templates: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Load session cookie and fetch input
-	sessionCookie, err := internal.LoadSessionCookie()
+	needInput := *force || !tx.Exists(filepath.Join(dayDir, "input"))
+	needScaffold := *force
+	for dir, dirFiles := range files {
+		for name := range dirFiles {
+			needScaffold = needScaffold || !tx.Exists(filepath.Join(dir, filepath.FromSlash(name)))
+		}
+	}
+
+	// Only the input needs the session cookie, the scaffold can be made
+	// offline
+	var input *string
+	if needInput {
+		sessionCookie, err := internal.LoadSessionCookie()
+		if err != nil {
+			fmt.Printf("Error loading session cookie: %v\n", err)
+			os.Exit(1)
+		}
+
+		inputContent, err := internal.FetchInput(dayNum, sessionCookie)
+		if err != nil {
+			fmt.Printf("Error fetching input: %v\n", err)
+			os.Exit(1)
+		}
+		input = &inputContent
+	}
+
+	if needScaffold {
+		templateData.Title, templateData.Examples = scaffoldPuzzle(dayNum, dayDir)
+	}
+
+	// Render the solution files for both solutions, with the title and
+	// examples this time
+	files, err = renderSolutions(lang, templateData, dayDir)
 	if err != nil {
-		fmt.Printf("Error loading session cookie: %v\n", err)
+		fmt.Printf("Error rendering templates: %v\n", err)
 		os.Exit(1)
 	}
 
-	inputContent, err := internal.FetchInput(dayNum, sessionCookie)
+	err = writeDay(tx, dayDir, input, files)
+	if err == nil && lang.Name == "go" {
+		err = writeRegistry(tx, templateData.Module)
+	}
 	if err != nil {
-		fmt.Printf("Error fetching input: %v\n", err)
+		fmt.Printf("Error: %v\n", err)
+		if err := tx.Rollback(); err != nil {
+			fmt.Printf("Error rolling back, check %s by hand: %v\n", dayDir, err)
+		} else {
+			fmt.Printf("Rolled back, nothing was changed\n")
+		}
 		os.Exit(1)
 	}
 
+	for _, action := range tx.Report {
+		fmt.Printf("  %-11s %s\n", action.Action, filepath.ToSlash(action.Path))
+	}
+
+	fmt.Printf("Successfully created directory structure for %s\n", dayDir)
+}
+
+// scaffoldPuzzle returns the title and examples of the puzzle for the
+// scaffold. They only make it nicer, so without a session cookie or network
+// it carries on with the title of the fetched puzzle, if there is one.
+func scaffoldPuzzle(dayNum int, dayDir string) (string, []internal.Example) {
+	sessionCookie, err := internal.LoadSessionCookie()
+	if err == nil {
+		var htmlContent string
+		if htmlContent, err = internal.FetchPuzzleHTML(dayNum, sessionCookie); err == nil {
+			return internal.ExtractPuzzleTitle(htmlContent), internal.ExtractExamples(htmlContent)
+		}
+	}
+	fmt.Printf("Warning: scaffolding without the puzzle's examples: %v\n", err)
+
+	content, _ := os.ReadFile(filepath.Join(dayDir, fmt.Sprintf("day%02d_content.txt", dayNum)))
+	return internal.PuzzleTitle(string(content)), nil
+}
+
+func solutionDirs(dayDir string) []string {
+	return []string{filepath.Join(dayDir, "ai"), filepath.Join(dayDir, "human")}
+}
+
+// renderSolutions renders the files of each solution directory, by
+// directory. Each is told its variant, Go ones import their own puzzle
+// package.
+func renderSolutions(lang internal.Language, data internal.TemplateData, dayDir string) (map[string]map[string][]byte, error) {
+	solutions := make(map[string]map[string][]byte)
+	for _, dir := range solutionDirs(dayDir) {
+		data.Variant = filepath.Base(dir)
+		files, err := internal.RenderScaffold(lang, data, internal.TemplatesDir)
+		if err != nil {
+			return nil, err
+		}
+
+		// Record how to build and run the solution for the other commands
+		files[internal.SolverFile] = lang.SolverConfig()
+		solutions[dir] = files
+	}
+
+	return solutions, nil
+}
+
+// writeDay lays out the day directory within the transaction. The input is
+// nil when it wasn't fetched because the day already has one.
+func writeDay(tx *internal.FileTransaction, dayDir string, input *string, solutions map[string]map[string][]byte) error {
+	if err := tx.Mkdir(dayDir); err != nil {
+		return fmt.Errorf("creating directory %s: %w", dayDir, err)
+	}
+
 	// Create input file with fetched content
 	inputFile := filepath.Join(dayDir, "input")
-	if err := os.WriteFile(inputFile, []byte(inputContent), 0644); err != nil {
-		fmt.Printf("Error creating input file: %v\n", err)
-		os.Exit(1)
+	if input != nil {
+		if err := tx.WriteFile(inputFile, []byte(*input), 0644); err != nil {
+			return fmt.Errorf("creating input file: %w", err)
+		}
+	} else {
+		tx.Report = append(tx.Report, internal.FileAction{Path: inputFile, Action: "skipped"})
 	}
 
-	answersFile := filepath.Join(dayDir, "answers")
-	if err := os.WriteFile(answersFile, []byte(""), 0644); err != nil {
-		fmt.Printf("Error creating answers file: %v\n", err)
-		os.Exit(1)
+	// The answers file is ours to fill in, never overwrite it
+	if err := tx.CreateFile(filepath.Join(dayDir, "answers"), []byte(""), 0644); err != nil {
+		return fmt.Errorf("creating answers file: %w", err)
 	}
 
-	// Create ai and human directories
-	aiDir := filepath.Join(dayDir, "ai")
-	if err := os.Mkdir(aiDir, 0755); err != nil {
-		fmt.Printf("Error creating ai directory: %v\n", err)
-		os.Exit(1)
+	for _, dir := range solutionDirs(dayDir) {
+		if err := tx.Mkdir(dir); err != nil {
+			return fmt.Errorf("creating directory %s: %w", dir, err)
+		}
+
+		files := solutions[dir]
+		for _, name := range internal.ScaffoldFileNames(files) {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if err := mkdirAll(tx, dir, filepath.Dir(path)); err != nil {
+				return err
+			}
+			if err := tx.WriteFile(path, files[name], 0644); err != nil {
+				return fmt.Errorf("creating %s: %w", name, err)
+			}
+		}
 	}
 
-	humanDir := filepath.Join(dayDir, "human")
-	if err := os.Mkdir(humanDir, 0755); err != nil {
-		fmt.Printf("Error creating human directory: %v\n", err)
-		os.Exit(1)
+	return nil
+}
+
+// writeRegistry generates the solvers registry again so it has the puzzle
+// packages just written.
+func writeRegistry(tx *internal.FileTransaction, module string) error {
+	entries, err := internal.RegistryEntries()
+	if err != nil {
+		return err
 	}
 
-	// Create main.go in human directory
-	mainGoContent := "package main\n"
-	mainGoFile := filepath.Join(humanDir, "main.go")
-	if err := os.WriteFile(mainGoFile, []byte(mainGoContent), 0644); err != nil {
-		fmt.Printf("Error creating main.go: %v\n", err)
-		os.Exit(1)
+	registry, err := internal.RenderRegistry(module, entries)
+	if err != nil {
+		return fmt.Errorf("rendering %s: %w", internal.RegistryFile, err)
 	}
 
-	// Create main_test.go in human directory
-	mainTestGoFile := filepath.Join(humanDir, "main_test.go")
-	if err := os.WriteFile(mainTestGoFile, []byte(mainGoContent), 0644); err != nil {
-		fmt.Printf("Error creating main_test.go: %v\n", err)
-		os.Exit(1)
+	if err := tx.Mkdir(filepath.Dir(internal.RegistryFile)); err != nil {
+		return fmt.Errorf("creating directory %s: %w", filepath.Dir(internal.RegistryFile), err)
+	}
+	if err := tx.ReplaceFile(internal.RegistryFile, registry, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", internal.RegistryFile, err)
 	}
 
-	fmt.Printf("Successfully created directory structure for %s\n", dayDir)
+	return nil
+}
+
+// mkdirAll creates the directories from root, which exists, down to dir
+// within the transaction.
+func mkdirAll(tx *internal.FileTransaction, root, dir string) error {
+	if dir == root {
+		return nil
+	}
+	if err := mkdirAll(tx, root, filepath.Dir(dir)); err != nil {
+		return err
+	}
+	if err := tx.Mkdir(dir); err != nil {
+		return fmt.Errorf("creating directory %s: %w", dir, err)
+	}
+
+	return nil
 }
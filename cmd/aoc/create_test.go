@@ -0,0 +1,25 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestScaffoldPuzzleOffline(t *testing.T) {
+	// Without a .env there is no session cookie, so the scaffold falls back
+	// to the title of the fetched puzzle
+	t.Chdir(t.TempDir())
+	writeFile(t, filepath.Join("day01", "day01_content.txt"), "--- Day 1: Secret Entrance ---\nThe Elves have good news.\n")
+
+	title, examples := scaffoldPuzzle(1, "day01")
+	if title != "Secret Entrance" {
+		t.Errorf("title = %q, want %q", title, "Secret Entrance")
+	}
+	if examples != nil {
+		t.Errorf("examples = %v, want none", examples)
+	}
+
+	if title, _ := scaffoldPuzzle(2, "day02"); title != "" {
+		t.Errorf("title without a fetched puzzle = %q, want none", title)
+	}
+}
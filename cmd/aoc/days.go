@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+// parseDays turns a day argument into day numbers. It accepts a single day
+// ("4"), a range ("1-6"), a comma separated list of either ("1,3-5") and
+// "all" for every day that has a directory.
+func parseDays(arg string) ([]int, error) {
+	if arg == "all" {
+		return internal.DayDirs()
+	}
+
+	var days []int
+	for part := range strings.SplitSeq(arg, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		if !isRange {
+			to = from
+		}
+
+		start, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return nil, fmt.Errorf("invalid day %q", part)
+		}
+
+		end, err := strconv.Atoi(strings.TrimSpace(to))
+		if err != nil {
+			return nil, fmt.Errorf("invalid day %q", part)
+		}
+
+		if start < 1 || end > 25 || start > end {
+			return nil, fmt.Errorf("day number must be between 1 and 25")
+		}
+
+		for d := start; d <= end; d++ {
+			days = append(days, d)
+		}
+	}
+
+	return days, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IanShearer/aoc/aocgen"
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+func diffTestDay() {
+	flags := flag.NewFlagSet("difftest", flag.ExitOnError)
+	n := flags.Int("n", 1000, "number of inputs to generate")
+	size := flags.Int("size", 10, "number of rotations, ranges, rows... in each input")
+	seed := flags.Uint64("seed", 0, "seed of the first input, the next ones count up from it, random when 0")
+	jobs := flags.Int("jobs", runtime.NumCPU(), "number of inputs run at the same time")
+	timeout := flags.Duration("timeout", 10*time.Second, "time a solver gets on an input before it counts as failing")
+	args := parseFlags(flags, os.Args[2:])
+
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: aoc difftest [-n 1000] [--size 10] [--seed N] <day_number>\n")
+		fmt.Fprintf(os.Stderr, "Example: aoc difftest 1 -n 1000\n")
+		os.Exit(1)
+	}
+
+	dayNum, err := strconv.Atoi(args[0])
+	if err != nil || dayNum < 1 || dayNum > 25 {
+		fmt.Fprintf(os.Stderr, "Error: day number must be between 1 and 25\n")
+		os.Exit(1)
+	}
+
+	gen, ok := aocgen.Lookup(dayNum)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: no input generator for day %d, there are for days %v\n", dayNum, aocgen.Days())
+		os.Exit(1)
+	}
+
+	var solvers [2]*internal.Solver
+	for i, name := range internal.SolverNames {
+		solver, err := internal.NewSolver(dayNum, name)
+		if err == nil {
+			err = solver.Build()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		solver.Timeout = *timeout
+		solvers[i] = solver
+	}
+
+	if *seed == 0 {
+		*seed = uint64(time.Now().UnixNano())
+	}
+
+	start := time.Now()
+	disagreements, err := internal.DiffTest(solvers, gen, *n, *size, *seed, *jobs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(disagreements) == 0 {
+		fmt.Printf("%s and %s agree on %d inputs (seed %d, %v)\n", solvers[0], solvers[1], *n, *seed, formatDuration(time.Since(start)))
+		return
+	}
+
+	fmt.Printf("%s and %s disagree on %d of %d inputs (seed %d)\n", solvers[0], solvers[1], len(disagreements), *n, *seed)
+
+	// the smallest of them shrinks the quickest
+	smallest := disagreements[0]
+	for _, d := range disagreements[1:] {
+		if len(d.Input.Bytes()) < len(smallest.Input.Bytes()) {
+			smallest = d
+		}
+	}
+
+	shrunk, tried, err := internal.Shrink(solvers, smallest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error shrinking: %v\n", err)
+		os.Exit(1)
+	}
+
+	input := string(shrunk.Input.Bytes())
+	fmt.Printf("\nSmallest input, shrunk from seed %d in %d tries (%d bytes):\n", shrunk.Seed, tried, len(input))
+	for _, line := range strings.Split(strings.TrimRight(input, "\n"), "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Println()
+	for i, solver := range solvers {
+		fmt.Printf("%-11s %s\n", solver.String()+":", shrunk.Outcomes[i])
+	}
+
+	os.Exit(1)
+}
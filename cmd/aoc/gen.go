@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/IanShearer/aoc/aocgen"
+)
+
+func genDay() {
+	flags := flag.NewFlagSet("gen", flag.ExitOnError)
+	size := flags.Int("size", 0, "number of rotations, ranges, rows... about as many as the real input when 0")
+	seed := flags.Uint64("seed", 1, "seed of the random input, the same seed gives the same input")
+	output := flags.String("output", "", "file to write the input to instead of stdout")
+	args := parseFlags(flags, os.Args[2:])
+
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: aoc gen [--size N] [--seed N] [--output path] <day_number>\n")
+		fmt.Fprintf(os.Stderr, "Example: aoc gen 4 --size 2000 --output big-input\n")
+		os.Exit(1)
+	}
+
+	dayNum, err := strconv.Atoi(args[0])
+	if err != nil || dayNum < 1 || dayNum > 25 {
+		fmt.Fprintf(os.Stderr, "Error: day number must be between 1 and 25\n")
+		os.Exit(1)
+	}
+
+	gen, ok := aocgen.Lookup(dayNum)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: no input generator for day %d, there are for days %v\n", dayNum, aocgen.Days())
+		os.Exit(1)
+	}
+	if *size == 0 {
+		*size = gen.RealSize()
+	}
+
+	input, err := aocgen.Generate(dayNum, *size, *seed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(input)
+		return
+	}
+
+	if err := os.WriteFile(*output, input, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing input: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote day %d input of size %d (seed %d) to %s\n", dayNum, *size, *seed, *output)
+}
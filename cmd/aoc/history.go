@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+func historyAudit() {
+	flags := flag.NewFlagSet("history-audit", flag.ExitOnError)
+	scriptPath := flags.String("script", "history-rewrite.sh", "where to write the script purging the leaks from history")
+	rewrite := flags.Bool("rewrite", false, "run the script once the audit is done, after asking for confirmation")
+	flags.Parse(os.Args[2:])
+
+	dayNums, err := internal.DayDirs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing days: %v\n", err)
+		os.Exit(1)
+	}
+
+	days := make(map[int]internal.DayData)
+	for _, dayNum := range dayNums {
+		days[dayNum] = internal.LoadDayData(dayNum)
+	}
+
+	leaks, err := internal.AuditHistory(days)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error auditing history: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(leaks) == 0 {
+		fmt.Println("No leaks found in history")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tBLOB\tCOMMITS\tLEAKS")
+	for _, leak := range leaks {
+		fmt.Fprintf(w, "%s\t%.7s\t%s\t%s\n", leak.Path, leak.Blob, strings.Join(leak.Commits, ","), strings.Join(leak.Reasons, ", "))
+	}
+	w.Flush()
+
+	// files that are clean at HEAD get their HEAD version in every commit,
+	// anything else is removed
+	replacements := make(map[string]string)
+	for _, leak := range leaks {
+		if _, ok := replacements[leak.Path]; ok || internal.IsSecretFile(leak.Path) {
+			continue
+		}
+
+		blob, err := internal.Git("rev-parse", "--verify", "--quiet", "HEAD:"+leak.Path)
+		if err != nil {
+			continue
+		}
+		replacements[leak.Path] = strings.TrimSpace(blob)
+	}
+
+	var stillLeaking []string
+	for _, leak := range leaks {
+		if replacements[leak.Path] == leak.Blob {
+			delete(replacements, leak.Path)
+			stillLeaking = append(stillLeaking, leak.Path)
+		}
+	}
+
+	if err := os.WriteFile(*scriptPath, []byte(rewriteScript(leaks, replacements)), 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing rewrite script: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nFound %d leaking version(s) of %d file(s)\n", len(leaks), countPaths(leaks))
+	for _, path := range stillLeaking {
+		fmt.Printf("Warning: %s still leaks at HEAD, run `aoc redact` and commit before rewriting or it will be removed\n", path)
+	}
+	fmt.Printf("Wrote %s, review it and run `sh %s --yes` to rewrite every branch\n", *scriptPath, *scriptPath)
+
+	if !*rewrite {
+		return
+	}
+
+	fmt.Print("This rewrites the history of every branch and needs a force push afterwards. Type yes to continue: ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if strings.TrimSpace(answer) != "yes" {
+		fmt.Println("Nothing was rewritten")
+		return
+	}
+
+	cmd := exec.Command("sh", *scriptPath, "--yes")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error rewriting history: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func countPaths(leaks []internal.HistoryLeak) int {
+	paths := make(map[string]bool)
+	for _, leak := range leaks {
+		paths[leak.Path] = true
+	}
+
+	return len(paths)
+}
+
+// rewriteScript returns a shell script purging the leaking blobs from every
+// branch with git filter-branch. The script calls itself as the index filter,
+// so paths are quoted once and no further.
+func rewriteScript(leaks []internal.HistoryLeak, replacements map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&sb, "# Generated by aoc history-audit on %s.\n", time.Now().Format("2006-01-02 15:04"))
+	sb.WriteString("# Purges leaked transcripts, inputs and answers from every branch. Versions\n")
+	sb.WriteString("# of a file that is clean at HEAD are swapped for the HEAD version, the\n")
+	sb.WriteString("# others are removed. The original refs are kept under refs/original.\n")
+	sb.WriteString("set -e\n\n")
+
+	sb.WriteString("purge() {\n")
+	sb.WriteString("\tif git ls-files -s -- \"$1\" | grep -q \" $2 \"; then\n")
+	sb.WriteString("\t\tif [ -n \"$3\" ]; then\n")
+	sb.WriteString("\t\t\tgit update-index --cacheinfo \"100644,$3,$1\"\n")
+	sb.WriteString("\t\telse\n")
+	sb.WriteString("\t\t\tgit rm --cached --quiet -- \"$1\"\n")
+	sb.WriteString("\t\tfi\n")
+	sb.WriteString("\tfi\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("if [ \"$1\" = \"--index-filter\" ]; then\n")
+	for _, leak := range leaks {
+		fmt.Fprintf(&sb, "\tpurge %s %s %s # %s\n", shellQuote(leak.Path), leak.Blob, shellQuote(replacements[leak.Path]), strings.Join(leak.Reasons, ", "))
+	}
+	sb.WriteString("\texit 0\n")
+	sb.WriteString("fi\n\n")
+
+	sb.WriteString("if [ \"$1\" != \"--yes\" ]; then\n")
+	sb.WriteString("\techo \"This rewrites the history of every branch, run it again with --yes to go ahead\" >&2\n")
+	sb.WriteString("\texit 1\n")
+	sb.WriteString("fi\n\n")
+
+	sb.WriteString("script=\"$(cd \"$(dirname \"$0\")\" && pwd)/$(basename \"$0\")\"\n")
+	sb.WriteString("git filter-branch --force --index-filter \"sh '$script' --index-filter\" -- --all\n\n")
+	sb.WriteString("echo \"Done. Check the result, then run git push --force --all\"\n")
+
+	return sb.String()
+}
+
+// shellQuote quotes s for sh, empty strings included.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(filepath.ToSlash(s), "'", `'\''`) + "'"
+}
@@ -0,0 +1,283 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BenchHistoryFile is where bench results are appended, a JSON object per
+// line.
+const BenchHistoryFile = "bench-history.jsonl"
+
+// Ways a solver is benchmarked.
+const (
+	BenchInProcess  = "in-process"
+	BenchSubprocess = "subprocess"
+)
+
+// BenchSample is one run of a solver.
+type BenchSample struct {
+	Wall time.Duration `json:"wall"`
+	// Parts are, as a subprocess, from the start of the run until each
+	// answer was printed and, in-process, each part solved on its own. They
+	// are 0 in-process when the solver can't solve its parts apart.
+	Parts [2]time.Duration `json:"parts"`
+	// Allocs and Bytes are only known in-process
+	Allocs uint64 `json:"allocs"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+// BenchResult sums up the runs of a solver at a commit.
+type BenchResult struct {
+	Commit string    `json:"commit"`
+	Dirty  bool      `json:"dirty,omitempty"`
+	Date   time.Time `json:"date"`
+	Day    int       `json:"day"`
+	Solver string    `json:"solver"`
+	Method string    `json:"method"`
+	Runs   int       `json:"runs"`
+	// Size and Seed are of the generated input benchmarked on, 0 for the
+	// day's input
+	Size int    `json:"size,omitempty"`
+	Seed uint64 `json:"seed,omitempty"`
+	// Median and P95 are of the whole run, Parts the median per part
+	Median time.Duration    `json:"median_ns"`
+	P95    time.Duration    `json:"p95_ns"`
+	Parts  [2]time.Duration `json:"parts_median_ns"`
+	Allocs uint64           `json:"allocs"`
+	Bytes  uint64           `json:"bytes"`
+}
+
+// Bench runs a built solver runs times on the day's input, or on inputPath
+// when it is set, in-process for Go solvers with a puzzle package and as a
+// subprocess otherwise.
+func (s *Solver) Bench(runs int, inputPath string) (BenchResult, error) {
+	result := BenchResult{Day: s.Day, Solver: s.Name, Runs: runs}
+
+	var samples []BenchSample
+	var err error
+	if s.InProcess() {
+		result.Method = BenchInProcess
+		samples, err = s.benchInProcess(runs, inputPath)
+	} else {
+		result.Method = BenchSubprocess
+		samples, err = s.benchSubprocess(runs, inputPath)
+	}
+	if err != nil {
+		return result, err
+	}
+	if len(samples) == 0 {
+		return result, fmt.Errorf("benchmarking %s: no runs", s)
+	}
+
+	walls := make([]time.Duration, len(samples))
+	var parts [2][]time.Duration
+	allocs := make([]uint64, len(samples))
+	allocBytes := make([]uint64, len(samples))
+	for i, sample := range samples {
+		walls[i] = sample.Wall
+		parts[0] = append(parts[0], sample.Parts[0])
+		parts[1] = append(parts[1], sample.Parts[1])
+		allocs[i] = sample.Allocs
+		allocBytes[i] = sample.Bytes
+	}
+
+	result.Median = Percentile(walls, 50)
+	result.P95 = Percentile(walls, 95)
+	result.Parts = [2]time.Duration{Percentile(parts[0], 50), Percentile(parts[1], 50)}
+	result.Allocs = Percentile(allocs, 50)
+	result.Bytes = Percentile(allocBytes, 50)
+	return result, nil
+}
+
+// benchInProcess runs the solvers package's BenchmarkSolvers for the
+// solver, once per run, so allocations can be counted and, when the solver
+// can solve its parts apart, each part timed on its own.
+func (s *Solver) benchInProcess(runs int, inputPath string) ([]BenchSample, error) {
+	if inputPath == "" {
+		inputPath = filepath.Join(filepath.Dir(s.Dir), "input")
+	}
+	input, err := filepath.Abs(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := fmt.Sprintf("^BenchmarkSolvers$/^day%02d$/^%s$", s.Day, regexp.QuoteMeta(s.Name))
+	cmd := exec.Command("go", "test", "-run", "^$", "-bench", pattern, "-benchtime=1x", fmt.Sprintf("-count=%d", runs), "-timeout=0", "./solvers")
+	cmd.Env = append(os.Environ(), "AOC_INPUT="+input)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("benchmarking %s: %w\n%s", s, err, strings.TrimSpace(string(out)))
+	}
+
+	samples, err := parseBenchOutput(out)
+	if err != nil {
+		return nil, fmt.Errorf("benchmarking %s: %w", s, err)
+	}
+	return samples, nil
+}
+
+// benchLineRegex matches a result of BenchmarkSolvers, e.g.
+// "BenchmarkSolvers/day01/ai/part1-8  1  52125 ns/op  1024 B/op  3 allocs/op",
+// capturing what was run and the numbers.
+var benchLineRegex = regexp.MustCompile(`^BenchmarkSolvers/day\d+/[^/\s]+/(solve|part1|part2)(?:-\d+)?\s+\d+\s+([\d.]+) ns/op\s+(\d+) B/op\s+(\d+) allocs/op`)
+
+// parseBenchOutput returns a sample per run of BenchmarkSolvers for one
+// solver, with the parts' times when they were benchmarked.
+func parseBenchOutput(out []byte) ([]BenchSample, error) {
+	var samples []BenchSample
+	var parts [2][]time.Duration
+	for _, line := range strings.Split(string(out), "\n") {
+		m := benchLineRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		ns, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			return nil, err
+		}
+		d := time.Duration(ns)
+
+		switch m[1] {
+		case "solve":
+			allocBytes, _ := strconv.ParseUint(m[3], 10, 64)
+			allocs, _ := strconv.ParseUint(m[4], 10, 64)
+			samples = append(samples, BenchSample{Wall: d, Allocs: allocs, Bytes: allocBytes})
+		case "part1":
+			parts[0] = append(parts[0], d)
+		case "part2":
+			parts[1] = append(parts[1], d)
+		}
+	}
+	if len(samples) == 0 {
+		return nil, errors.New("no results in the output of go test")
+	}
+
+	for i := range samples {
+		for part := range parts {
+			if i < len(parts[part]) {
+				samples[i].Parts[part] = parts[part][i]
+			}
+		}
+	}
+	return samples, nil
+}
+
+func (s *Solver) benchSubprocess(runs int, inputPath string) ([]BenchSample, error) {
+	var samples []BenchSample
+	for range runs {
+		result, err := s.Run(inputPath)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, BenchSample{Wall: result.Wall, Parts: result.Times})
+	}
+
+	return samples, nil
+}
+
+// Percentile returns the nearest-rank p-th percentile of values.
+func Percentile[T time.Duration | uint64](values []T, p int) T {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// GitCommit returns the short hash of HEAD and whether tracked files have
+// changes on top of it.
+func GitCommit() (string, bool, error) {
+	commit, err := Git("rev-parse", "--short", "HEAD")
+	if err != nil {
+		return "", false, err
+	}
+
+	status, err := Git("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return "", false, err
+	}
+
+	return strings.TrimSpace(commit), strings.TrimSpace(status) != "", nil
+}
+
+// ReadBenchHistory reads the bench history file, oldest first. A missing
+// file is an empty history.
+func ReadBenchHistory(path string) ([]BenchResult, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var history []BenchResult
+	for i, line := range strings.Split(string(b), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var result BenchResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, i+1, err)
+		}
+		history = append(history, result)
+	}
+
+	return history, nil
+}
+
+// AppendBenchHistory appends results to the bench history file.
+func AppendBenchHistory(path string, results []BenchResult) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	encoder := json.NewEncoder(file)
+	for _, result := range results {
+		errs = append(errs, encoder.Encode(result))
+	}
+
+	return errors.Join(append(errs, file.Close())...)
+}
+
+// PreviousBench returns the latest result of the same solver, method and
+// input in history from another commit, or from the clean tree of the same
+// commit.
+func PreviousBench(history []BenchResult, result BenchResult) (BenchResult, bool) {
+	for i := len(history) - 1; i >= 0; i-- {
+		h := history[i]
+		if h.Day == result.Day && h.Solver == result.Solver && h.Method == result.Method && h.Size == result.Size && h.Seed == result.Seed && (h.Commit != result.Commit || h.Dirty != result.Dirty) {
+			return h, true
+		}
+	}
+
+	return BenchResult{}, false
+}
+
+// Slowdown returns how much slower, as a fraction, the median of result is
+// than the one of previous.
+func (r BenchResult) Slowdown(previous BenchResult) float64 {
+	if previous.Median <= 0 {
+		return 0
+	}
+
+	return float64(r.Median-previous.Median) / float64(previous.Median)
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []time.Duration{5, 1, 4, 2, 3, 10, 9, 8, 7, 6}
+
+	for p, want := range map[int]time.Duration{50: 5, 95: 10, 0: 1, 100: 10} {
+		if got := Percentile(values, p); got != want {
+			t.Errorf("Percentile(%d) = %v, expected %v", p, got, want)
+		}
+	}
+
+	if got := Percentile([]uint64{}, 50); got != 0 {
+		t.Errorf("expected the percentile of nothing to be 0, got %d", got)
+	}
+}
+
+func TestBenchHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), BenchHistoryFile)
+
+	history, err := ReadBenchHistory(path)
+	if err != nil || len(history) != 0 {
+		t.Fatalf("expected a missing history to be empty, got %v, %v", history, err)
+	}
+
+	old := BenchResult{Commit: "aaaaaaa", Day: 5, Solver: "human", Method: BenchInProcess, Median: 100}
+	other := BenchResult{Commit: "aaaaaaa", Day: 5, Solver: "ai", Method: BenchInProcess, Median: 10}
+	if err := AppendBenchHistory(path, []BenchResult{old, other}); err != nil {
+		t.Fatal(err)
+	}
+
+	current := BenchResult{Commit: "bbbbbbb", Day: 5, Solver: "human", Method: BenchInProcess, Median: 125}
+	if err := AppendBenchHistory(path, []BenchResult{current}); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err = ReadBenchHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(history))
+	}
+
+	// the result of the same commit doesn't count as the previous one
+	previous, ok := PreviousBench(history, current)
+	if !ok || previous.Commit != "aaaaaaa" {
+		t.Fatalf("expected the previous result to be from aaaaaaa, got %+v", previous)
+	}
+	if got := current.Slowdown(previous); got != 0.25 {
+		t.Errorf("expected a 0.25 slowdown, got %v", got)
+	}
+
+	if _, ok := PreviousBench(history[:1], old); ok {
+		t.Errorf("expected no previous result for the first commit")
+	}
+
+	// results on generated inputs are only compared with the same input
+	generated := current
+	generated.Size, generated.Seed = 2000, 1
+	if _, ok := PreviousBench(history, generated); ok {
+		t.Errorf("expected no previous result on a generated input")
+	}
+}
+
+func TestParseBenchOutput(t *testing.T) {
+	out := `goos: linux
+BenchmarkSolvers/day07/ai/solve-8   	       1	      2000 ns/op	     512 B/op	       4 allocs/op
+BenchmarkSolvers/day07/ai/solve-8   	       1	      3000 ns/op	     512 B/op	       4 allocs/op
+BenchmarkSolvers/day07/ai/part1-8   	       1	      1200 ns/op	     256 B/op	       2 allocs/op
+BenchmarkSolvers/day07/ai/part1-8   	       1	      1100 ns/op	     256 B/op	       2 allocs/op
+BenchmarkSolvers/day07/ai/part2-8   	       1	       900 ns/op	     256 B/op	       2 allocs/op
+BenchmarkSolvers/day07/ai/part2-8   	       1	       800 ns/op	     256 B/op	       2 allocs/op
+PASS
+`
+	got, err := parseBenchOutput([]byte(out))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []BenchSample{
+		{Wall: 2000, Parts: [2]time.Duration{1200, 900}, Allocs: 4, Bytes: 512},
+		{Wall: 3000, Parts: [2]time.Duration{1100, 800}, Allocs: 4, Bytes: 512},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseBenchOutput() = %+v, want %+v", got, want)
+	}
+
+	// Without parts only the whole solve is timed
+	got, err = parseBenchOutput([]byte("BenchmarkSolvers/day01/human/solve \t1\t 700 ns/op\t 0 B/op\t 0 allocs/op\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []BenchSample{{Wall: 700}}; !slices.Equal(got, want) {
+		t.Errorf("parseBenchOutput() = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseBenchOutput([]byte("FAIL\n")); err == nil {
+		t.Error("expected an error without results")
+	}
+}
@@ -0,0 +1,332 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	gotoken "go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AnswerPackage loads answers from the gitignored answers file at run time,
+// fixed literals call into it.
+const AnswerPackage = "github.com/IanShearer/aoc/aocanswer"
+
+// CodeAnswer is an answer hardcoded in a Go file, in a literal or a comment.
+type CodeAnswer struct {
+	Path   string
+	Line   int
+	Column int
+	Part   int
+	// Where is "literal" or "comment"
+	Where string
+	// Fix is the text to write instead, empty when it can't be fixed
+	Fix string
+
+	start, end int
+}
+
+func (a CodeAnswer) String() string {
+	fix := "report only"
+	if a.Fix != "" {
+		fix = "fix: " + a.Fix
+	}
+
+	return fmt.Sprintf("%s:%d:%d: part %d answer in a %s (%s)", a.Path, a.Line, a.Column, a.Part, a.Where, fix)
+}
+
+// The solutions' imports are type checked from source, the module's own
+// packages have no export data, once for every package checked.
+var (
+	codeFileSet  = gotoken.NewFileSet()
+	codeImporter = importer.ForCompiler(codeFileSet, "source", nil)
+)
+
+// FindCodeAnswers looks for answers in the Go files of a package directory.
+// The package is type checked so numbers can be swapped for a call of the
+// right type; when it doesn't type check the answers are still reported and
+// warnf, when set, is told why.
+func FindCodeAnswers(dir string, answers []string, warnf func(format string, args ...any)) ([]CodeAnswer, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := codeFileSet
+	packages := make(map[string][]*ast.File)
+	var files []*ast.File
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		packages[file.Name.Name] = append(packages[file.Name.Name], file)
+		files = append(files, file)
+	}
+
+	// main and main_test are checked separately, the errors of one don't
+	// stop the other
+	info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+	for name, pkgFiles := range packages {
+		conf := types.Config{Importer: codeImporter, Error: func(err error) {
+			if warnf != nil {
+				warnf("type checking %s: %v", dir, err)
+			}
+		}}
+		conf.Check(name, fset, pkgFiles, info)
+	}
+
+	var found []CodeAnswer
+	for _, file := range files {
+		found = append(found, findFileAnswers(fset, file, answers, info)...)
+	}
+
+	return found, nil
+}
+
+func findFileAnswers(fset *gotoken.FileSet, file *ast.File, answers []string, info *types.Info) []CodeAnswer {
+	var found []CodeAnswer
+	add := func(pos gotoken.Pos, end gotoken.Pos, part int, where string, fix string) {
+		p := fset.Position(pos)
+		found = append(found, CodeAnswer{
+			Path:   filepath.ToSlash(p.Filename),
+			Line:   p.Line,
+			Column: p.Column,
+			Part:   part,
+			Where:  where,
+			Fix:    fix,
+			start:  p.Offset,
+			end:    fset.Position(end).Offset,
+		})
+	}
+
+	isTest := strings.HasSuffix(fset.Position(file.Pos()).Filename, "_test.go")
+
+	var constant []ast.Node
+	var stack []ast.Node
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+
+		// values of constants must stay constant, we can only report them
+		if decl, ok := n.(*ast.GenDecl); ok && decl.Tok == gotoken.CONST {
+			constant = append(constant, decl)
+		}
+
+		lit, ok := n.(*ast.BasicLit)
+		if !ok {
+			return true
+		}
+
+		part := literalAnswer(lit, answers)
+		if part == 0 {
+			return true
+		}
+
+		fix := ""
+		inConst := false
+		for _, decl := range constant {
+			if lit.Pos() >= decl.Pos() && lit.End() <= decl.End() {
+				inConst = true
+			}
+		}
+		// tests skip without the answers file, which needs their testing.T;
+		// anywhere else in a test file we can only report them
+		t := testingParam(stack)
+		if !inConst && (!isTest || t != "") {
+			fix = literalFix(lit, part, info.Types[lit].Type, t)
+		}
+
+		add(lit.Pos(), lit.End(), part, "literal", fix)
+		return true
+	})
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			for part, answer := range answers {
+				if len(answer) < minAnswerLength {
+					continue
+				}
+
+				for _, m := range answerPattern(answer).FindAllStringSubmatchIndex(comment.Text, -1) {
+					pos := comment.Pos() + gotoken.Pos(m[2])
+					add(pos, pos+gotoken.Pos(len(answer)), part+1, "comment", redactedPlaceholder(""))
+				}
+			}
+		}
+	}
+
+	return found
+}
+
+// literalAnswer returns the part a literal is the answer of, 0 when it isn't
+// one.
+func literalAnswer(lit *ast.BasicLit, answers []string) int {
+	value := lit.Value
+	if lit.Kind == gotoken.STRING {
+		s, err := strconv.Unquote(value)
+		if err != nil {
+			return 0
+		}
+		value = strings.TrimSpace(s)
+	}
+
+	for i, answer := range answers {
+		if len(answer) >= minAnswerLength && value == answer {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// literalFix returns a call loading the answer with the type the literal has
+// in its context. In a test, t is the name of its testing.T, B or TB, and the
+// call skips the test when there is no answer.
+func literalFix(lit *ast.BasicLit, part int, typ types.Type, t string) string {
+	basic, ok := typ.(*types.Basic)
+	if !ok {
+		return ""
+	}
+
+	str, integer, args := "String", "Int", strconv.Itoa(part)
+	if t != "" {
+		str, integer, args = "TestString", "TestInt", t+", "+args
+	}
+
+	switch {
+	case lit.Kind == gotoken.STRING && basic.Info()&types.IsString != 0:
+		return fmt.Sprintf("aocanswer.%s(%s)", str, args)
+	case lit.Kind == gotoken.INT && basic.Info()&types.IsInteger != 0 && basic.Info()&types.IsUntyped == 0:
+		return fmt.Sprintf("aocanswer.%s[%s](%s)", integer, basic.Name(), args)
+	case lit.Kind == gotoken.INT && basic.Kind() == types.UntypedInt:
+		return fmt.Sprintf("aocanswer.%s[int](%s)", integer, args)
+	}
+
+	return ""
+}
+
+// testingParam returns the name of the *testing.T, *testing.B or testing.TB
+// parameter of the innermost function of the stack that has one.
+func testingParam(stack []ast.Node) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var typ *ast.FuncType
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			typ = fn.Type
+		case *ast.FuncLit:
+			typ = fn.Type
+		default:
+			continue
+		}
+
+		for _, field := range typ.Params.List {
+			expr := field.Type
+			if star, ok := expr.(*ast.StarExpr); ok {
+				expr = star.X
+			}
+			sel, ok := expr.(*ast.SelectorExpr)
+			if !ok || len(field.Names) == 0 || field.Names[0].Name == "_" {
+				continue
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "testing" && (sel.Sel.Name == "T" || sel.Sel.Name == "B" || sel.Sel.Name == "TB") {
+				return field.Names[0].Name
+			}
+		}
+	}
+
+	return ""
+}
+
+// answerPattern matches an answer on its own, not as part of a longer number
+// or word.
+func answerPattern(answer string) *regexp.Regexp {
+	return regexp.MustCompile(`(?:^|[^0-9A-Za-z])(` + regexp.QuoteMeta(answer) + `)(?:$|[^0-9A-Za-z])`)
+}
+
+// FixCodeAnswers rewrites a file with the fixable answers replaced and the
+// aocanswer import added when needed.
+func FixCodeAnswers(path string, found []CodeAnswer) ([]byte, int, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var fixes []CodeAnswer
+	for _, a := range found {
+		if a.Path == filepath.ToSlash(path) && a.Fix != "" {
+			fixes = append(fixes, a)
+		}
+	}
+	if len(fixes) == 0 {
+		return src, 0, nil
+	}
+
+	sort.Slice(fixes, func(i, j int) bool {
+		return fixes[i].start > fixes[j].start
+	})
+
+	calls := false
+	for _, a := range fixes {
+		src = append(src[:a.start], append([]byte(a.Fix), src[a.end:]...)...)
+		calls = calls || a.Where == "literal"
+	}
+
+	if calls && !bytes.Contains(src, []byte(strconv.Quote(AnswerPackage))) {
+		src, err = addImport(src, AnswerPackage)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return formatted, len(fixes), nil
+}
+
+// addImport adds an import to the imports of a file, or a new import
+// declaration after the package clause when there are none.
+func addImport(src []byte, path string) ([]byte, error) {
+	fset := gotoken.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != gotoken.IMPORT {
+			continue
+		}
+
+		// in a group of its own after the standard library
+		if gen.Lparen.IsValid() {
+			offset := fset.Position(gen.Rparen).Offset
+			return append(src[:offset], append([]byte("\n\t"+strconv.Quote(path)+"\n"), src[offset:]...)...), nil
+		}
+
+		// a single import becomes a block
+		start, end := fset.Position(gen.Pos()).Offset, fset.Position(gen.End()).Offset
+		spec := string(src[fset.Position(gen.Specs[0].Pos()).Offset:end])
+		block := "import (\n\t" + spec + "\n\n\t" + strconv.Quote(path) + "\n)"
+		return append(src[:start], append([]byte(block), src[end:]...)...), nil
+	}
+
+	offset := fset.Position(file.Name.End()).Offset
+	return append(src[:offset], append([]byte("\n\nimport "+strconv.Quote(path)+"\n"), src[offset:]...)...), nil
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const solution = `package main
+
+import "fmt"
+
+const expected = 1052
+
+// part one is 1052, part two is 6295
+func main() {
+	var total int64 = 6295
+	fmt.Println(total, "1052", 10520)
+}
+`
+
+func TestCodeAnswers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte(solution), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindCodeAnswers(dir, []string{"1052", "6295"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, a := range found {
+		got = append(got, strings.TrimPrefix(a.String(), filepath.ToSlash(dir)+"/"))
+	}
+
+	expected := []string{
+		"main.go:5:18: part 1 answer in a literal (report only)",
+		"main.go:9:20: part 2 answer in a literal (fix: aocanswer.Int[int64](2))",
+		"main.go:10:21: part 1 answer in a literal (fix: aocanswer.String(1))",
+		"main.go:7:16: part 1 answer in a comment (fix: (REDACTED))",
+		"main.go:7:34: part 2 answer in a comment (fix: (REDACTED))",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected answers.\n\nExpecting:\n%s\n\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	fixed, n, err := FixCodeAnswers(path, found)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n != 4 {
+		t.Errorf("expected 4 fixes, got %d", n)
+	}
+
+	for _, want := range []string{
+		"import (\n\t\"fmt\"\n\n\t\"github.com/IanShearer/aoc/aocanswer\"\n)",
+		"const expected = 1052",
+		"// part one is (REDACTED), part two is (REDACTED)",
+		"var total int64 = aocanswer.Int[int64](2)",
+		"fmt.Println(total, aocanswer.String(1), 10520)",
+	} {
+		if !strings.Contains(string(fixed), want) {
+			t.Errorf("expected fixed file to contain %q, got:\n%s", want, fixed)
+		}
+	}
+}
+
+const solutionTest = `package main
+
+import "testing"
+
+var real = []int{6295}
+
+func TestPartOne(t *testing.T) {
+	if got := partOne("input"); got != 1052 {
+		t.Errorf("expected 1052, got %d", got)
+	}
+}
+
+func partOne(string) int { return 0 }
+`
+
+func TestCodeAnswersInTests(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main_test.go")
+	if err := os.WriteFile(path, []byte(solutionTest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindCodeAnswers(dir, []string{"1052", "6295"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, a := range found {
+		got = append(got, strings.TrimPrefix(a.String(), filepath.ToSlash(dir)+"/"))
+	}
+
+	// outside of a test there is no testing.T to skip with
+	expected := []string{
+		"main_test.go:5:18: part 2 answer in a literal (report only)",
+		"main_test.go:8:37: part 1 answer in a literal (fix: aocanswer.TestInt[int](t, 1))",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected answers.\n\nExpecting:\n%s\n\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestCodeAnswersTypeErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte("package main\n\nvar total int = \"1052\" + missing\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var warnings []string
+	found, err := FindCodeAnswers(dir, []string{"1052"}, func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the answer is still reported, and why it can't be fixed is told
+	if len(found) != 1 || len(warnings) == 0 || !strings.Contains(warnings[0], "missing") {
+		t.Errorf("expected the answer and a type error, got %v and %q", found, warnings)
+	}
+}
@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/IanShearer/aoc/aocgen"
+)
+
+// Outcome is what a solver answered on an input, or why it didn't.
+type Outcome struct {
+	Answers [2]string
+	Error   string
+}
+
+func (o Outcome) String() string {
+	if o.Error != "" {
+		return "error: " + o.Error
+	}
+	return fmt.Sprintf("Part One: %s, Part Two: %s", o.Answers[0], o.Answers[1])
+}
+
+// Disagreement is an input two solvers answered differently.
+type Disagreement struct {
+	Seed     uint64
+	Input    aocgen.Input
+	Outcomes [2]Outcome
+}
+
+// DiffTest runs two built solvers on inputs from seed to seed+n-1, jobs at a
+// time, and returns the inputs they disagree on, by seed.
+func DiffTest(solvers [2]*Solver, gen aocgen.Generator, n, size int, seed uint64, jobs int) ([]Disagreement, error) {
+	tmp, err := os.MkdirTemp("", "aoc-difftest-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	found := make([]*Disagreement, n)
+	errs := make([]error, n)
+	sem := make(chan struct{}, max(jobs, 1))
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			s := seed + uint64(i)
+			input := gen.Generate(aocgen.NewRand(s), size)
+			outcomes, disagree, err := compare(solvers, input, filepath.Join(tmp, fmt.Sprintf("input-%d", i)))
+			if disagree {
+				found[i] = &Disagreement{Seed: s, Input: input, Outcomes: outcomes}
+			}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+
+	var disagreements []Disagreement
+	for i, d := range found {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if d != nil {
+			disagreements = append(disagreements, *d)
+		}
+	}
+
+	return disagreements, nil
+}
+
+// Shrink cuts the input of a disagreement down for as long as the solvers
+// still disagree, and returns the smallest one found with the number of
+// inputs tried.
+func Shrink(solvers [2]*Solver, d Disagreement) (Disagreement, int, error) {
+	tmp, err := os.MkdirTemp("", "aoc-shrink-")
+	if err != nil {
+		return d, 0, err
+	}
+	defer os.RemoveAll(tmp)
+
+	tried := 0
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for _, smaller := range d.Input.Shrink() {
+			tried++
+			outcomes, disagree, err := compare(solvers, smaller, filepath.Join(tmp, "input"))
+			if err != nil {
+				return d, tried, err
+			}
+			if disagree {
+				d.Input, d.Outcomes = smaller, outcomes
+				shrunk = true
+				break
+			}
+		}
+	}
+
+	return d, tried, nil
+}
+
+// compare runs both solvers on an input written to path. They disagree when
+// their answers differ or only one of them fails, inputs both fail on are
+// taken as ones the generator shouldn't have made.
+func compare(solvers [2]*Solver, input aocgen.Input, path string) ([2]Outcome, bool, error) {
+	var outcomes [2]Outcome
+	if err := os.WriteFile(path, input.Bytes(), 0644); err != nil {
+		return outcomes, false, err
+	}
+	defer os.Remove(path)
+
+	for i, solver := range solvers {
+		result, err := solver.Run(path)
+		if err != nil {
+			outcomes[i].Error = err.Error()
+			continue
+		}
+		outcomes[i].Answers = result.Answers
+	}
+
+	a, b := outcomes[0], outcomes[1]
+	if a.Error != "" || b.Error != "" {
+		return outcomes, (a.Error == "") != (b.Error == ""), nil
+	}
+
+	return outcomes, a.Answers != b.Answers, nil
+}
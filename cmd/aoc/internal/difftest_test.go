@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/IanShearer/aoc/aocgen"
+)
+
+// Scripts standing in for built solvers, reading the input from AOC_INPUT.
+const (
+	countLines  = "#!/bin/sh\necho \"Part One: $(grep -c . \"$AOC_INPUT\")\"\necho \"Part Two: 0\"\n"
+	skipSevens  = "#!/bin/sh\necho \"Part One: $(grep -v 7 \"$AOC_INPUT\" | grep -c .)\"\necho \"Part Two: 0\"\n"
+	alwaysFails = "#!/bin/sh\necho oops >&2\nexit 1\n"
+)
+
+func scriptSolver(t *testing.T, name, script string) *Solver {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "day01", name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "solution"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return &Solver{Day: 1, Name: name, Dir: dir, Language: Language{Name: "sh", Binary: "solution"}}
+}
+
+// numbers generates lines of numbers below 100.
+type numbers struct{}
+
+func (numbers) RealSize() int { return 10 }
+
+func (numbers) Generate(r *rand.Rand, size int) aocgen.Input {
+	items := make([]int, size)
+	for i := range items {
+		items[i] = r.IntN(100)
+	}
+	return numberItems(items...)
+}
+
+func numberItems(items ...int) aocgen.Items[int] {
+	return aocgen.Items[int]{
+		Items: items,
+		Format: func(items []int) []byte {
+			var b strings.Builder
+			for _, item := range items {
+				b.WriteString(strconv.Itoa(item) + "\n")
+			}
+			return []byte(b.String())
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		scripts  [2]string
+		input    []int
+		disagree bool
+	}{
+		{"same answers", [2]string{countLines, skipSevens}, []int{1, 2, 3}, false},
+		{"different answers", [2]string{countLines, skipSevens}, []int{1, 17, 3}, true},
+		{"only one fails", [2]string{countLines, alwaysFails}, []int{1}, true},
+		// an input both fail on is a bad input, not a disagreement
+		{"both fail", [2]string{alwaysFails, alwaysFails}, []int{1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvers := [2]*Solver{scriptSolver(t, "ai", tt.scripts[0]), scriptSolver(t, "human", tt.scripts[1])}
+
+			outcomes, disagree, err := compare(solvers, numberItems(tt.input...), filepath.Join(t.TempDir(), "input"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if disagree != tt.disagree {
+				t.Errorf("disagree = %v, want %v, outcomes %v", disagree, tt.disagree, outcomes)
+			}
+		})
+	}
+}
+
+func TestDiffTest(t *testing.T) {
+	solvers := [2]*Solver{scriptSolver(t, "ai", countLines), scriptSolver(t, "human", skipSevens)}
+
+	const n, size, seed = 20, 5, 1
+	disagreements, err := DiffTest(solvers, numbers{}, n, size, seed, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// exactly the inputs with a 7 in them, in seed order
+	var want []uint64
+	for s := uint64(seed); s < seed+n; s++ {
+		if strings.Contains(string(numbers{}.Generate(aocgen.NewRand(s), size).Bytes()), "7") {
+			want = append(want, s)
+		}
+	}
+	var got []uint64
+	for _, d := range disagreements {
+		got = append(got, d.Seed)
+	}
+	if len(want) == 0 || !slices.Equal(got, want) {
+		t.Fatalf("disagreements on seeds %v, want %v", got, want)
+	}
+
+	shrunk, tried, err := Shrink(solvers, disagreements[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Fields(string(shrunk.Input.Bytes()))
+	if len(lines) != 1 || !strings.Contains(lines[0], "7") {
+		t.Errorf("expected a single number with a 7 after %d tries, got %q", tried, shrunk.Input.Bytes())
+	}
+	if shrunk.Outcomes[0].Answers[0] != "1" || shrunk.Outcomes[1].Answers[0] != "0" {
+		t.Errorf("unexpected outcomes of the shrunk input %v", shrunk.Outcomes)
+	}
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Git runs a git command in the current directory and returns its stdout.
+func Git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// StagedFiles returns the paths of the files added, copied, modified or
+// renamed in the index.
+func StagedFiles() ([]string, error) {
+	out, err := Git("diff", "--cached", "--name-only", "--diff-filter=ACMR", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+
+	return files, nil
+}
+
+// StagedContent returns the content of a file as it is in the index.
+func StagedContent(path string) (string, error) {
+	return Git("show", ":"+path)
+}
+
+// PreCommitHook is the git hook aoc verify --install-hook installs, running
+// verify on what is about to be committed.
+const PreCommitHook = "#!/bin/sh\n" + hookMarker + "\nexec go run ./cmd/aoc verify --staged\n"
+
+const hookMarker = "# installed by aoc verify --install-hook"
+
+// InstallPreCommitHook writes PreCommitHook to the repository's hooks and
+// returns where. A hook aoc didn't install is never overwritten.
+func InstallPreCommitHook() (string, error) {
+	hooksDir, err := Git("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+
+	hookPath := filepath.Join(strings.TrimSpace(hooksDir), "pre-commit")
+	if existing, err := os.ReadFile(hookPath); err == nil && !strings.Contains(string(existing), hookMarker) {
+		return "", fmt.Errorf("%s already exists and was not installed by aoc", hookPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(hookPath), 0755); err != nil {
+		return "", err
+	}
+
+	return hookPath, os.WriteFile(hookPath, []byte(PreCommitHook), 0755)
+}
+
+// GitBlobs returns the content of the given blobs, read with a single
+// git cat-file --batch.
+func GitBlobs(blobs []string) (map[string]string, error) {
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Stdin = strings.NewReader(strings.Join(blobs, "\n") + "\n")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file --batch: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseCatFileBatch(out)
+}
+
+// parseCatFileBatch splits the output of git cat-file --batch, a
+// "<sha> <type> <size>" header followed by the content and a newline for
+// each object.
+func parseCatFileBatch(out []byte) (map[string]string, error) {
+	contents := make(map[string]string)
+	for len(out) > 0 {
+		end := bytes.IndexByte(out, '\n')
+		if end == -1 {
+			return nil, fmt.Errorf("git cat-file --batch: truncated header")
+		}
+
+		var sha, kind string
+		var size int
+		header := string(out[:end])
+		if _, err := fmt.Sscanf(header, "%s %s %d", &sha, &kind, &size); err != nil {
+			return nil, fmt.Errorf("git cat-file --batch: %q: %w", header, err)
+		}
+
+		out = out[end+1:]
+		if size+1 > len(out) {
+			return nil, fmt.Errorf("git cat-file --batch: truncated %s", sha)
+		}
+
+		contents[sha] = string(out[:size])
+		out = out[size+1:]
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testRepo makes a git repository in a temporary directory and moves there.
+// It returns helpers to write files and run git, failing the test on errors.
+func testRepo(t *testing.T) (write func(path, content string), git func(args ...string)) {
+	t.Chdir(t.TempDir())
+	for _, env := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(env, "aoc")
+	}
+	for _, env := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(env, "aoc@example.com")
+	}
+
+	write = func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git = func(args ...string) {
+		if _, err := Git(args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	return write, git
+}
+
+func TestStagedFiles(t *testing.T) {
+	write, git := testRepo(t)
+
+	write("kept.go", "package main\n")
+	write("modified.go", "package main\n")
+	write("renamed.go", "package main\n\nfunc renamed() {}\n")
+	write("deleted.go", "package main\n")
+	git("add", "-A")
+	git("commit", "-qm", "initial")
+
+	write("added.go", "package main\n")
+	write("modified.go", "package main\n\n// staged\n")
+	git("mv", "renamed.go", "moved.go")
+	git("rm", "-q", "deleted.go")
+	git("add", "added.go", "modified.go")
+
+	// changes that are not staged aren't what gets committed
+	write("kept.go", "package main\n\n// not staged\n")
+	write("modified.go", "package main\n\n// not staged\n")
+	write("untracked.go", "package main\n")
+
+	files, err := StagedFiles()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slices.Sort(files)
+	expected := []string{"added.go", "modified.go", "moved.go"}
+	if !slices.Equal(files, expected) {
+		t.Errorf("expected staged files %v, got %v", expected, files)
+	}
+
+	content, err := StagedContent("modified.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != "package main\n\n// staged\n" {
+		t.Errorf("expected the staged content, got %q", content)
+	}
+}
+
+func TestInstallPreCommitHook(t *testing.T) {
+	testRepo(t)
+
+	hookPath, err := InstallPreCommitHook()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filepath.ToSlash(hookPath) != ".git/hooks/pre-commit" {
+		t.Errorf("unexpected hook path %s", hookPath)
+	}
+
+	hook, err := os.ReadFile(hookPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(hook), "#!/bin/sh\n") || !strings.Contains(string(hook), "aoc verify --staged") {
+		t.Errorf("unexpected hook:\n%s", hook)
+	}
+	if info, err := os.Stat(hookPath); err != nil || info.Mode()&0100 == 0 {
+		t.Errorf("expected an executable hook, got %v, %v", info, err)
+	}
+
+	// installing again updates our own hook
+	if _, err := InstallPreCommitHook(); err != nil {
+		t.Errorf("unexpected error reinstalling: %v", err)
+	}
+
+	// somebody else's hook is left alone
+	if err := os.WriteFile(hookPath, []byte("#!/bin/sh\nmake lint\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InstallPreCommitHook(); err == nil {
+		t.Errorf("expected an error overwriting another hook")
+	}
+	if hook, _ := os.ReadFile(hookPath); string(hook) != "#!/bin/sh\nmake lint\n" {
+		t.Errorf("another hook was overwritten:\n%s", hook)
+	}
+}
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// HistoryLeak is a version of a file, somewhere in the git history, that
+// leaks the data of a day.
+type HistoryLeak struct {
+	Path string
+	Blob string
+	// Reasons are the distinct kinds of leak, e.g. "day 01 answer"
+	Reasons []string
+	// Commits are the ones adding or removing this version of the file
+	Commits []string
+}
+
+// IsSecretFile tells if a path is a file that must never be committed at
+// all, a day's input or answers.
+func IsSecretFile(path string) bool {
+	if DayOfPath(path) == 0 {
+		return false
+	}
+
+	switch filepath.Base(path) {
+	case "input", "input.txt", "answers":
+		return true
+	}
+
+	return false
+}
+
+// AuditHistory looks at every version of every file ever committed, on any
+// branch, and returns the ones leaking an input, an answer or the puzzle.
+// A version that was renamed or copied leaks at each of its paths. Only
+// plain git commands are used.
+func AuditHistory(days map[int]DayData) ([]HistoryLeak, error) {
+	history, err := historyBlobs()
+	if err != nil {
+		return nil, err
+	}
+
+	// only versions that were a scannable or secret file somewhere are read
+	var blobs []string
+	for sha, paths := range history {
+		for path := range paths {
+			if IsSecretFile(path) || IsScannable(path) {
+				blobs = append(blobs, sha)
+				break
+			}
+		}
+	}
+
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	sort.Strings(blobs)
+
+	contents, err := GitBlobs(blobs)
+	if err != nil {
+		return nil, err
+	}
+
+	var leaks []HistoryLeak
+	for _, sha := range blobs {
+		for path, commits := range history[sha] {
+			reasons := leakReasons(path, contents[sha], days)
+			if len(reasons) == 0 {
+				continue
+			}
+
+			leaks = append(leaks, HistoryLeak{
+				Path:    path,
+				Blob:    sha,
+				Reasons: reasons,
+				Commits: commits,
+			})
+		}
+	}
+
+	sort.Slice(leaks, func(i, j int) bool {
+		if leaks[i].Path != leaks[j].Path {
+			return leaks[i].Path < leaks[j].Path
+		}
+		return leaks[i].Blob < leaks[j].Blob
+	})
+
+	return leaks, nil
+}
+
+// leakReasons returns the distinct kinds of leak of a version of a file.
+func leakReasons(path string, content string, days map[int]DayData) []string {
+	if IsSecretFile(path) {
+		return []string{fmt.Sprintf("%s file", filepath.Base(path))}
+	}
+
+	var check []DayData
+	if dayNum := DayOfPath(path); dayNum != 0 {
+		if day, ok := days[dayNum]; ok {
+			check = append(check, day)
+		}
+	} else {
+		for _, day := range days {
+			check = append(check, day)
+		}
+	}
+
+	var reasons []string
+	seen := make(map[string]bool)
+	for _, leak := range FindLeaks(path, content, check) {
+		if !seen[leak.Reason] {
+			seen[leak.Reason] = true
+			reasons = append(reasons, leak.Reason)
+		}
+	}
+	sort.Strings(reasons)
+
+	return reasons
+}
+
+// historyBlobs returns every blob of every branch, with each path it was
+// at and the commits adding or removing it there. Renames are listed as a
+// removal and an addition, so each path of a blob is found.
+func historyBlobs() (map[string]map[string][]string, error) {
+	// refs/original is the backup filter-branch leaves behind after a rewrite
+	out, err := Git("log", "--exclude=refs/original/*", "--all", "--root", "-m", "--no-renames", "--raw", "--no-abbrev", "--format=%H", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[string]map[string][]string)
+	add := func(sha, path, commit string) {
+		if strings.Trim(sha, "0") == "" {
+			return
+		}
+		if history[sha] == nil {
+			history[sha] = make(map[string][]string)
+		}
+
+		// merges are listed once per parent
+		commits := history[sha][path]
+		if len(commits) == 0 || commits[len(commits)-1] != commit {
+			history[sha][path] = append(commits, commit)
+		}
+	}
+
+	// -z gives the commit, then ":<modes> <old> <new> <status>" and the path
+	// for each change, all NUL terminated
+	var commit string
+	tokens := strings.Split(out, "\x00")
+	for i := 0; i < len(tokens); i++ {
+		token := strings.TrimPrefix(tokens[i], "\n")
+		if !strings.HasPrefix(token, ":") {
+			if token != "" {
+				commit = token[:min(len(token), 7)]
+			}
+			continue
+		}
+
+		fields := strings.Fields(token)
+		if len(fields) < 5 || i+1 >= len(tokens) {
+			return nil, fmt.Errorf("git log: unexpected change %q", token)
+		}
+		i++
+		path := filepath.ToSlash(tokens[i])
+		add(fields[2], path, commit)
+		add(fields[3], path, commit)
+	}
+
+	return history, nil
+}
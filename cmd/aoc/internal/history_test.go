@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestParseCatFileBatch(t *testing.T) {
+	out := "aaa blob 5\nhello\nbbb blob 0\n\nccc blob 8\ntwo\nline\n"
+
+	got, err := parseCatFileBatch([]byte(out))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 3 || got["aaa"] != "hello" || got["bbb"] != "" || got["ccc"] != "two\nline" {
+		t.Errorf("unexpected contents: %q", got)
+	}
+
+	if _, err := parseCatFileBatch([]byte("aaa blob 10\nshort\n")); err == nil {
+		t.Errorf("expected an error for truncated output")
+	}
+}
+
+func TestAuditHistory(t *testing.T) {
+	write, git := testRepo(t)
+
+	write("day01/ai/day01_conversation.txt", "  ⎿  Part One: 1052\n")
+	write("day01/input", "L68\nR30\n")
+	write("day01/ai/main.go", "package main\n")
+	git("add", "-A")
+	git("commit", "-qm", "leak")
+
+	write("day01/ai/day01_conversation.txt", "  ⎿  Part One: (REDACTED)\n")
+	git("rm", "-q", "--cached", "day01/input")
+	git("commit", "-qam", "fix")
+
+	leaks, err := AuditHistory(map[int]DayData{1: {Day: 1, Answers: []string{"1052"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(leaks) != 2 {
+		t.Fatalf("expected 2 leaks, got %+v", leaks)
+	}
+
+	if leaks[0].Path != "day01/ai/day01_conversation.txt" || leaks[0].Reasons[0] != "day 01 answer" || len(leaks[0].Commits) != 2 {
+		t.Errorf("unexpected transcript leak: %+v", leaks[0])
+	}
+
+	if leaks[1].Path != "day01/input" || leaks[1].Reasons[0] != "input file" {
+		t.Errorf("unexpected input leak: %+v", leaks[1])
+	}
+}
+
+func TestAuditHistoryRenamed(t *testing.T) {
+	write, git := testRepo(t)
+
+	write("day01/ai/conversation.txt", "  ⎿  Part One: 1052\n")
+	git("add", "-A")
+	git("commit", "-qm", "leak")
+
+	// moved, then copied somewhere that isn't scanned by name
+	git("mv", "day01/ai/conversation.txt", "day01/ai/day01_conversation.txt")
+	git("commit", "-qm", "rename")
+	write("day01/notes.txt", "  ⎿  Part One: 1052\n")
+	git("add", "-A")
+	git("commit", "-qm", "copy")
+
+	write("day01/ai/day01_conversation.txt", "  ⎿  Part One: (REDACTED)\n")
+	git("rm", "-q", "day01/notes.txt")
+	git("commit", "-qam", "fix")
+
+	leaks, err := AuditHistory(map[int]DayData{1: {Day: 1, Answers: []string{"1052"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"day01/ai/conversation.txt", "day01/ai/day01_conversation.txt", "day01/notes.txt"}
+	if len(leaks) != len(expected) {
+		t.Fatalf("expected %d leaks, got %+v", len(expected), leaks)
+	}
+
+	for i, path := range expected {
+		if leaks[i].Path != path || leaks[i].Blob != leaks[0].Blob || len(leaks[i].Commits) != 2 {
+			t.Errorf("unexpected leak of %s: %+v", path, leaks[i])
+		}
+	}
+}
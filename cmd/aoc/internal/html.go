@@ -102,3 +102,65 @@ func HtmlToText(htmlContent string) string {
 
 	return htmlContent
 }
+
+var (
+	titleRegex   = regexp.MustCompile(`--- Day \d+: (.*?) ---`)
+	articleRegex = regexp.MustCompile(`(?is)<article[^>]*>(.*?)</article>`)
+	exampleRegex = regexp.MustCompile(`(?is)<pre><code>(.*?)</code></pre>`)
+	// the example's result is the last emphasised code of a part
+	exampleAnswerRegex = regexp.MustCompile(`(?is)<code><em>(.*?)</em></code>`)
+	numberRegex        = regexp.MustCompile(`^-?\d+$`)
+	htmlTagRegex       = regexp.MustCompile(`<[^>]+>`)
+)
+
+// Example is an example input of the puzzle with its expected answers. An
+// answer is empty when the puzzle doesn't give one, or it isn't a number.
+type Example struct {
+	Input   string
+	PartOne string
+	PartTwo string
+}
+
+// ExtractPuzzleTitle returns the title of the puzzle, "Secret Entrance" for
+// "--- Day 1: Secret Entrance ---".
+func ExtractPuzzleTitle(htmlContent string) string {
+	m := titleRegex.FindStringSubmatch(HtmlToText(htmlContent))
+	if m == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(m[1])
+}
+
+// ExtractExamples returns the example of each part of the puzzle that has
+// been unlocked so far. Part two usually reuses the part one example.
+func ExtractExamples(htmlContent string) []Example {
+	var examples []Example
+	for part, article := range articleRegex.FindAllStringSubmatch(htmlContent, 2) {
+		input := ""
+		if m := exampleRegex.FindStringSubmatch(article[1]); m != nil {
+			input = strings.TrimRight(html.UnescapeString(htmlTagRegex.ReplaceAllString(m[1], "")), "\n")
+		}
+
+		answer := ""
+		if ms := exampleAnswerRegex.FindAllStringSubmatch(article[1], -1); ms != nil {
+			answer = html.UnescapeString(htmlTagRegex.ReplaceAllString(ms[len(ms)-1][1], ""))
+		}
+		if !numberRegex.MatchString(answer) {
+			answer = ""
+		}
+
+		switch {
+		case part == 0:
+			if input != "" {
+				examples = append(examples, Example{Input: input, PartOne: answer})
+			}
+		case len(examples) > 0 && (input == "" || input == examples[0].Input):
+			examples[0].PartTwo = answer
+		case input != "":
+			examples = append(examples, Example{Input: input, PartTwo: answer})
+		}
+	}
+
+	return examples
+}
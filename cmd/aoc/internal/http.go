@@ -6,8 +6,11 @@ import (
 	"net/http"
 )
 
+// Year is the Advent of Code event this repository solves.
+const Year = 2025
+
 func FetchInput(dayNum int, sessionCookie string) (string, error) {
-	url := fmt.Sprintf("https://adventofcode.com/2025/day/%d/input", dayNum)
+	url := fmt.Sprintf("https://adventofcode.com/%d/day/%d/input", Year, dayNum)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -40,7 +43,7 @@ func FetchInput(dayNum int, sessionCookie string) (string, error) {
 }
 
 func FetchPuzzleHTML(dayNum int, sessionCookie string) (string, error) {
-	url := fmt.Sprintf("https://adventofcode.com/2025/day/%d", dayNum)
+	url := fmt.Sprintf("https://adventofcode.com/%d/day/%d", Year, dayNum)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
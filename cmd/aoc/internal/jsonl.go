@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// IsJSONL tells if a conversation is a raw session log, one JSON object per
+// line, rather than a pasted transcript.
+func IsJSONL(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// the first line decides, a pasted transcript never starts with an object
+		return strings.HasPrefix(line, "{") && json.Valid([]byte(line))
+	}
+
+	return false
+}
+
+// RewriteJSONLStrings calls fn on the value of every string in every line and
+// writes back the ones that changed. Everything else, object keys, numbers,
+// whitespace and key order included, is kept byte for byte. The returned
+// edits turn the result back into content.
+func RewriteJSONLStrings(content string, fn func(s string, line int) string) (string, []Edit, error) {
+	var edits []Edit
+
+	lines := strings.Split(content, "\n")
+	offset := 0
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			rewritten, lineEdits, err := rewriteJSONStrings(line, func(s string) string {
+				return fn(s, i+1)
+			})
+			if err != nil {
+				return "", nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+
+			if !json.Valid([]byte(rewritten)) {
+				return "", nil, fmt.Errorf("line %d: redaction produced invalid JSON", i+1)
+			}
+
+			for _, e := range lineEdits {
+				e.Offset += offset
+				edits = append(edits, e)
+			}
+			lines[i] = rewritten
+		}
+
+		offset += len(lines[i]) + 1
+	}
+
+	return strings.Join(lines, "\n"), edits, nil
+}
+
+func rewriteJSONStrings(line string, fn func(string) string) (string, []Edit, error) {
+	var edits []Edit
+	var sb strings.Builder
+	i := 0
+	for i < len(line) {
+		if line[i] != '"' {
+			sb.WriteByte(line[i])
+			i++
+			continue
+		}
+
+		// find the closing quote, skipping escaped characters
+		end := i + 1
+		for end < len(line) && line[end] != '"' {
+			if line[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(line) {
+			return "", nil, fmt.Errorf("unterminated string at column %d", i+1)
+		}
+
+		raw := line[i : end+1]
+		i = end + 1
+
+		// keys are followed by a colon, leave them alone
+		if strings.HasPrefix(strings.TrimLeft(line[i:], " \t\r"), ":") {
+			sb.WriteString(raw)
+			continue
+		}
+
+		var value string
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return "", nil, err
+		}
+
+		redacted := fn(value)
+		if redacted == value {
+			sb.WriteString(raw)
+			continue
+		}
+
+		quoted, err := quoteJSON(redacted)
+		if err != nil {
+			return "", nil, err
+		}
+		edits = append(edits, Edit{Offset: sb.Len(), Redacted: quoted, Original: raw})
+		sb.WriteString(quoted)
+	}
+
+	return sb.String(), edits, nil
+}
+
+func quoteJSON(s string) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
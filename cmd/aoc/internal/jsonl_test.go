@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const sessionLog = `{"type":"user","message":{"role":"user","content":"Please answer part one.\n` + "```" + `\nThe Elves need you to find the password. How many times does the dial point at 0? For example, turn left then right.\n` + "```" + `"},"cwd":"/home/ian/fun/aoc/2025/day01/ai"}
+{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Bash","input":{"command":"go run main.go"}}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","content":"Part One: 1234\n\tPart Two: \"5678\" ✓","is_error":false}]},"1234":1234}
+`
+
+func TestIsJSONL(t *testing.T) {
+	if !IsJSONL(sessionLog) {
+		t.Errorf("expected session log to be detected as JSONL")
+	}
+
+	if IsJSONL("\n> You are working on day01 of advent of code 2025.\n") {
+		t.Errorf("expected transcript not to be detected as JSONL")
+	}
+}
+
+func TestRedactJSONL(t *testing.T) {
+	r := Redactor{DayNum: 1, Answers: []string{"1234", "5678"}}
+
+	got, _, err := r.RedactJSONL(sessionLog)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+
+	for i, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("line %d is not valid JSON: %s", i+1, line)
+		}
+	}
+
+	// the tool_use line has nothing to redact and must be untouched
+	if lines[1] != strings.Split(sessionLog, "\n")[1] {
+		t.Errorf("expected untouched line, got: %s", lines[1])
+	}
+
+	var result struct {
+		Message struct {
+			Content []struct {
+				Content string `json:"content"`
+			} `json:"content"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "Part One: (REDACTED)\n\tPart Two: \"(REDACTED)\" ✓"
+	if result.Message.Content[0].Content != expected {
+		t.Errorf("unexpected redaction.\n\nExpecting: %q\nGot: %q", expected, result.Message.Content[0].Content)
+	}
+
+	// keys and numbers are not strings we redact
+	if !strings.HasSuffix(lines[2], `"1234":1234}`) {
+		t.Errorf("expected keys and numbers to be kept, got: %s", lines[2])
+	}
+
+	if strings.Contains(lines[0], "/home/ian") || !strings.Contains(lines[0], "puzzle, part one of advent of code 2025 day 01") {
+		t.Errorf("expected puzzle and home directory to be redacted, got: %s", lines[0])
+	}
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// SolverFile records, in a solver directory, how to build and run it.
+const SolverFile = "solver.json"
+
+// Language is a language solutions can be written in.
+type Language struct {
+	Name string `json:"language"`
+	// Marker is a file only a solver in this language has, used to tell
+	// the language of solvers created before solver.json existed
+	Marker string `json:"-"`
+	// Build, Test and Binary are run in, and relative to, the solver
+	// directory
+	Build  []string `json:"build"`
+	Test   []string `json:"test"`
+	Binary string   `json:"binary"`
+	// Sources are patterns of the names of the files aoc watch reruns the
+	// solver for
+	Sources []string `json:"sources,omitempty"`
+}
+
+var languages = map[string]Language{}
+
+// RegisterLanguage adds a language to the registry. Its templates are the
+// built-in templates/<name> directory, overridden by TemplatesDir/<name>.
+func RegisterLanguage(l Language) {
+	languages[l.Name] = l
+}
+
+func init() {
+	RegisterLanguage(Language{
+		Name:    "go",
+		Marker:  "main.go",
+		Build:   []string{"go", "build", "-o", "solution", "."},
+		Test:    []string{"go", "test", "./..."},
+		Binary:  "solution",
+		Sources: []string{"*.go", "go.mod"},
+	})
+
+	RegisterLanguage(Language{
+		Name:    "rust",
+		Marker:  "Cargo.toml",
+		Build:   []string{"cargo", "build", "--release", "--quiet"},
+		Test:    []string{"cargo", "test", "--quiet"},
+		Binary:  filepath.Join("target", "release", "solution"),
+		Sources: []string{"*.rs", "Cargo.toml"},
+	})
+}
+
+// LookupLanguage returns a registered language by name.
+func LookupLanguage(name string) (Language, error) {
+	l, ok := languages[name]
+	if !ok {
+		return Language{}, fmt.Errorf("unknown language %q, expected one of %v", name, LanguageNames())
+	}
+
+	return l, nil
+}
+
+// LanguageNames returns the names of the registered languages.
+func LanguageNames() []string {
+	var names []string
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// SolverConfig returns the content of the solver.json recording how a
+// solver in this language is built and run.
+func (l Language) SolverConfig() []byte {
+	b, _ := json.MarshalIndent(l, "", "  ")
+	return append(b, '\n')
+}
+
+// SolverLanguage returns how to build and run the solver in dir: what its
+// solver.json says, or else the language whose marker file it has.
+func SolverLanguage(dir string) (Language, error) {
+	b, err := os.ReadFile(filepath.Join(dir, SolverFile))
+	if err == nil {
+		var l Language
+		if err := json.Unmarshal(b, &l); err != nil {
+			return Language{}, fmt.Errorf("%s: %w", filepath.Join(dir, SolverFile), err)
+		}
+		// solver.json files from before sources were recorded
+		if known, ok := languages[l.Name]; ok && len(l.Sources) == 0 {
+			l.Sources = known.Sources
+		}
+		return l, nil
+	}
+	if !os.IsNotExist(err) {
+		return Language{}, err
+	}
+
+	for _, name := range LanguageNames() {
+		l := languages[name]
+		if _, err := os.Stat(filepath.Join(dir, l.Marker)); err == nil {
+			return l, nil
+		}
+	}
+
+	return Language{}, fmt.Errorf("%s has no %s and no solution we recognise", dir, SolverFile)
+}
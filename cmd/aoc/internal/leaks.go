@@ -0,0 +1,224 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal/transcript"
+)
+
+const (
+	// answers shorter than this match too much code to be worth reporting
+	minAnswerLength = 3
+	// an input line shorter than this is not distinctive enough on its own
+	minInputLineLength = 8
+	// number of consecutive input lines before we call it a copy of the input
+	minInputRun = 3
+	// size of the windows we look for when an input line is very long
+	inputWindow = 64
+)
+
+var dayPathRegex = regexp.MustCompile(`^day(\d{2})/`)
+
+// DayData is what we know about a day that must never be committed.
+type DayData struct {
+	Day     int
+	Answers []string
+	Puzzle  *PuzzleShingles
+	Input   []string
+}
+
+// LoadDayData reads the answers, fetched puzzle and input of a day. Any of
+// them may be missing, in which case that check is skipped.
+func LoadDayData(dayNum int) DayData {
+	dayDir := fmt.Sprintf("day%02d", dayNum)
+	data := DayData{Day: dayNum}
+
+	if answers, err := ReadAnswers(filepath.Join(dayDir, "answers")); err == nil {
+		data.Answers = answers
+	}
+
+	if puzzle, err := os.ReadFile(filepath.Join(dayDir, fmt.Sprintf("day%02d_content.txt", dayNum))); err == nil && len(puzzle) > 0 {
+		p := NewPuzzleShingles(string(puzzle))
+		data.Puzzle = &p
+	}
+
+	if input, err := os.ReadFile(filepath.Join(dayDir, "input")); err == nil {
+		for _, line := range strings.Split(string(input), "\n") {
+			data.Input = append(data.Input, strings.TrimSpace(line))
+		}
+	}
+
+	return data
+}
+
+// DayDirs returns the day numbers that have a dayNN directory.
+func DayDirs() ([]int, error) {
+	dirs, err := filepath.Glob("day[0-9][0-9]")
+	if err != nil {
+		return nil, err
+	}
+
+	var days []int
+	for _, dir := range dirs {
+		var dayNum int
+		if _, err := fmt.Sscanf(dir, "day%02d", &dayNum); err == nil {
+			days = append(days, dayNum)
+		}
+	}
+
+	return days, nil
+}
+
+// Leak is a place in a file where an answer, the puzzle or the input shows up.
+type Leak struct {
+	Path    string
+	Line    int
+	Reason  string
+	Snippet string
+}
+
+func (l Leak) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", l.Path, l.Line, l.Reason, l.Snippet)
+}
+
+// IsScannable tells if a file is one we commit and could leak into: the
+// conversations and session logs, Go sources and tests, and markdown.
+func IsScannable(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasSuffix(base, "_conversation.txt") ||
+		strings.HasSuffix(base, ".jsonl") ||
+		strings.HasSuffix(base, ".go") ||
+		strings.HasSuffix(base, ".md")
+}
+
+// DayOfPath returns the day a repository path belongs to, or 0 when it is
+// outside of every dayNN directory.
+func DayOfPath(path string) int {
+	m := dayPathRegex.FindStringSubmatch(filepath.ToSlash(path))
+	if m == nil {
+		return 0
+	}
+
+	var dayNum int
+	fmt.Sscanf(m[1], "%d", &dayNum)
+	return dayNum
+}
+
+// FindLeaks scans the content of a file against the data of the given days.
+func FindLeaks(path string, content string, days []DayData) []Leak {
+	var leaks []Leak
+	for _, day := range days {
+		leaks = append(leaks, findAnswerLeaks(path, content, day)...)
+		leaks = append(leaks, findPuzzleLeaks(path, content, day)...)
+		leaks = append(leaks, findInputLeaks(path, content, day)...)
+	}
+
+	return leaks
+}
+
+func findAnswerLeaks(path string, content string, day DayData) []Leak {
+	var leaks []Leak
+	for _, answer := range day.Answers {
+		if len(answer) < minAnswerLength {
+			continue
+		}
+
+		for _, m := range answerPattern(answer).FindAllStringSubmatchIndex(content, -1) {
+			leaks = append(leaks, newLeak(path, content, m[2], fmt.Sprintf("day %02d answer", day.Day)))
+		}
+	}
+
+	return leaks
+}
+
+func findPuzzleLeaks(path string, content string, day DayData) []Leak {
+	if day.Puzzle == nil {
+		return nil
+	}
+
+	var leaks []Leak
+	for _, span := range day.Puzzle.Find(content) {
+		leaks = append(leaks, newLeak(path, content, span.Start, fmt.Sprintf("day %02d puzzle text", day.Day)))
+	}
+
+	return leaks
+}
+
+func findInputLeaks(path string, content string, day DayData) []Leak {
+	if len(day.Input) == 0 {
+		return nil
+	}
+
+	inputLines := make(map[string]bool)
+	for _, line := range day.Input {
+		if len(line) >= minInputLineLength {
+			inputLines[line] = true
+		}
+	}
+
+	var leaks []Leak
+
+	// runs of whole input lines, e.g. a pasted grid or a Read tool output
+	lines := strings.Split(content, "\n")
+	run := 0
+	for i, line := range lines {
+		// the first line of a tool output starts with its marker
+		line = strings.TrimPrefix(strings.TrimSpace(line), transcript.OutputMarker)
+		if inputLines[strings.TrimSpace(line)] {
+			run++
+			if run == minInputRun {
+				start := i - minInputRun + 1
+				leaks = append(leaks, Leak{
+					Path:    path,
+					Line:    start + 1,
+					Reason:  fmt.Sprintf("day %02d input", day.Day),
+					Snippet: snippet(lines[start]),
+				})
+			}
+			continue
+		}
+		run = 0
+	}
+
+	// pieces of very long input lines, like day02's single line of ranges
+	for _, line := range day.Input {
+		for i := 0; i+inputWindow <= len(line); i += inputWindow / 2 {
+			if idx := strings.Index(content, line[i:i+inputWindow]); idx != -1 {
+				leaks = append(leaks, newLeak(path, content, idx, fmt.Sprintf("day %02d input", day.Day)))
+				break
+			}
+		}
+	}
+
+	return leaks
+}
+
+func newLeak(path string, content string, offset int, reason string) Leak {
+	lineStart := strings.LastIndex(content[:offset], "\n") + 1
+	lineEnd := strings.Index(content[offset:], "\n")
+	if lineEnd == -1 {
+		lineEnd = len(content)
+	} else {
+		lineEnd += offset
+	}
+
+	return Leak{
+		Path:    path,
+		Line:    strings.Count(content[:offset], "\n") + 1,
+		Reason:  reason,
+		Snippet: snippet(content[lineStart:lineEnd]),
+	}
+}
+
+func snippet(line string) string {
+	runes := []rune(strings.TrimSpace(line))
+	if len(runes) > 80 {
+		return string(runes[:77]) + "..."
+	}
+
+	return string(runes)
+}
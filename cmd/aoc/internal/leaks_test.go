@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindLeaks(t *testing.T) {
+	p := NewPuzzleShingles(puzzle)
+	day := DayData{
+		Day:     1,
+		Answers: []string{"1052", "42"},
+		Puzzle:  &p,
+		Input:   []string{"L68 R30 L5 R48 L99", "R14 L82 L1 R60 R7", "L55 R12 L30 R3 R9", "L7"},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "answer in a go file",
+			path:     "day01/ai/main_test.go",
+			content:  "package main\n\nfunc TestPartOne(t *testing.T) {\n\tif got := partOne(input); got != 1052 {\n",
+			expected: []string{"day01/ai/main_test.go:4: day 01 answer: if got := partOne(input); got != 1052 {"},
+		},
+		{
+			name:    "answer too short to report",
+			path:    "day01/ai/main.go",
+			content: "package main\n\nconst size = 42\n",
+		},
+		{
+			name:    "answer inside a longer number",
+			path:    "day01/ai/main.go",
+			content: "package main\n\nconst big = 10520\n",
+		},
+		{
+			name:     "verbatim puzzle",
+			path:     "day01/README.md",
+			content:  "# Day 1\n\n" + strings.Split(puzzle, "\n\n")[0] + "\n",
+			expected: []string{"day01/README.md:3: day 01 puzzle text: --- Day 1: Secret Entrance ---"},
+		},
+		{
+			name:     "input lines",
+			path:     "day01/ai/day01_conversation.txt",
+			content:  "● Read(input)\n  ⎿  L68 R30 L5 R48 L99\n     R14 L82 L1 R60 R7\n     L55 R12 L30 R3 R9\n",
+			expected: []string{"day01/ai/day01_conversation.txt:2: day 01 input: ⎿  L68 R30 L5 R48 L99"},
+		},
+		{
+			name:    "clean",
+			path:    "day01/ai/main.go",
+			content: "package main\n\n// countZeros turns the dial and counts how often it stops at 0.\nfunc countZeros(rotations []int) int {\n\treturn 0\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, leak := range FindLeaks(tt.path, tt.content, []DayData{day}) {
+				got = append(got, leak.String())
+			}
+
+			if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("unexpected leaks.\n\nExpecting:\n%s\n\nGot:\n%s", strings.Join(tt.expected, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestLoadDayDataGuesses(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.Mkdir("day01", 0755); err != nil {
+		t.Fatal(err)
+	}
+	answers := `{"parts": [{"part": 1, "accepted": "1052", "rejected": [{"answer": "1100", "feedback": "too high"}]}]}`
+	if err := os.WriteFile(filepath.Join("day01", "answers"), []byte(answers), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	leaks := FindLeaks("day01/ai/main.go", "package main\n\n// not 1100\n", []DayData{LoadDayData(1)})
+	if len(leaks) != 1 {
+		t.Errorf("expected the rejected guess to be a leak, got %v", leaks)
+	}
+}
+
+func TestDayOfPath(t *testing.T) {
+	for path, expected := range map[string]int{
+		"day01/ai/main.go":       1,
+		"day12/answers":          12,
+		"cmd/aoc/main.go":        0,
+		"solvers/day01/solve.go": 0,
+	} {
+		if got := DayOfPath(path); got != expected {
+			t.Errorf("%s: expected day %d, got %d", path, expected, got)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Redaction is one placeholder written in place of some original text. Line
+// and Column point at the placeholder in the redacted file.
+type Redaction struct {
+	ID       int    `json:"id"`
+	Rule     string `json:"rule"`
+	Original string `json:"original"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+// Edit undoes part of a redaction run: Redacted is found at Offset in the
+// redacted file and is replaced by Original.
+type Edit struct {
+	Offset   int    `json:"offset"`
+	Redacted string `json:"redacted"`
+	Original string `json:"original"`
+}
+
+// replacement is a piece of text a redaction pass wants gone.
+type replacement struct {
+	start, end int
+	rule       string
+	// placeholder builds the text written in place of the original. The tag
+	// is "#N" when redactions are numbered, empty otherwise.
+	placeholder func(tag string) string
+	// width, when set, pads the placeholder with spaces to that many runes,
+	// so a line drawn in a box keeps its border where it was
+	width int
+}
+
+func redactedPlaceholder(tag string) string {
+	return fmt.Sprintf("(REDACTED%s)", tag)
+}
+
+type span struct {
+	start, end int
+	redaction  Redaction
+}
+
+// ledger applies the replacements of successive redaction passes to a text
+// and keeps track of where each placeholder ended up, so the whole run can
+// be undone exactly.
+type ledger struct {
+	// numbered placeholders look like (REDACTED#3)
+	numbered bool
+	// shared between ledgers so IDs are unique within a file
+	ids   *int
+	spans []span
+}
+
+func newLedger(numbered bool, ids *int) *ledger {
+	if ids == nil {
+		ids = new(int)
+	}
+
+	return &ledger{numbered: numbered, ids: ids}
+}
+
+func (l *ledger) apply(text string, reps []replacement) string {
+	sort.SliceStable(reps, func(i, j int) bool {
+		return reps[i].start < reps[j].start
+	})
+
+	// A replacement inside an existing placeholder is dropped (an answer
+	// matching the digits of "#12"), one partly covering a placeholder grows
+	// to swallow it whole (puzzle text around a redacted answer).
+	var kept []replacement
+	for _, rep := range reps {
+		inside := false
+		for _, s := range l.spans {
+			if rep.start >= s.start && rep.end <= s.end {
+				inside = true
+				break
+			}
+			if rep.start < s.end && rep.end > s.start {
+				rep.start = min(rep.start, s.start)
+				rep.end = max(rep.end, s.end)
+			}
+		}
+
+		if inside || (len(kept) > 0 && rep.start < kept[len(kept)-1].end) {
+			continue
+		}
+		kept = append(kept, rep)
+	}
+
+	var sb strings.Builder
+	var spans []span
+	prev := 0
+	delta := 0
+	old := l.spans
+	for _, rep := range kept {
+		// spans before this replacement only move
+		for len(old) > 0 && old[0].end <= rep.start {
+			s := old[0]
+			s.start += delta
+			s.end += delta
+			spans = append(spans, s)
+			old = old[1:]
+		}
+
+		// spans inside it are folded into its original text
+		original := text[rep.start:rep.end]
+		var inner []span
+		for len(old) > 0 && old[0].start < rep.end {
+			inner = append(inner, old[0])
+			old = old[1:]
+		}
+		if len(inner) > 0 {
+			original = restoreSpans(text, rep.start, rep.end, inner)
+		}
+
+		*l.ids++
+		tag := ""
+		if l.numbered {
+			tag = fmt.Sprintf("#%d", *l.ids)
+		}
+		placeholder := rep.placeholder(tag)
+		if pad := rep.width - utf8.RuneCountInString(placeholder); pad > 0 {
+			placeholder += strings.Repeat(" ", pad)
+		}
+
+		sb.WriteString(text[prev:rep.start])
+		start := sb.Len()
+		sb.WriteString(placeholder)
+		spans = append(spans, span{
+			start: start,
+			end:   sb.Len(),
+			redaction: Redaction{
+				ID:       *l.ids,
+				Rule:     rep.rule,
+				Original: original,
+			},
+		})
+
+		delta += len(placeholder) - (rep.end - rep.start)
+		prev = rep.end
+	}
+	sb.WriteString(text[prev:])
+
+	for _, s := range old {
+		s.start += delta
+		s.end += delta
+		spans = append(spans, s)
+	}
+	l.spans = spans
+
+	return sb.String()
+}
+
+// restoreSpans returns text[start:end] with the placeholders of the given
+// spans swapped back for their original text.
+func restoreSpans(text string, start, end int, spans []span) string {
+	var sb strings.Builder
+	prev := start
+	for _, s := range spans {
+		sb.WriteString(text[prev:s.start])
+		sb.WriteString(s.redaction.Original)
+		prev = s.end
+	}
+	sb.WriteString(text[prev:end])
+
+	return sb.String()
+}
+
+// redactions returns the placeholders of the text, with their position.
+func (l *ledger) redactions(text string) []Redaction {
+	redactions := make([]Redaction, 0, len(l.spans))
+	for _, s := range l.spans {
+		r := s.redaction
+		r.Line = strings.Count(text[:s.start], "\n") + 1
+		r.Column = s.start - (strings.LastIndex(text[:s.start], "\n") + 1) + 1
+		redactions = append(redactions, r)
+	}
+
+	return redactions
+}
+
+// edits returns the edits undoing every placeholder of the text.
+func (l *ledger) edits(text string, base int) []Edit {
+	edits := make([]Edit, 0, len(l.spans))
+	for _, s := range l.spans {
+		edits = append(edits, Edit{
+			Offset:   base + s.start,
+			Redacted: text[s.start:s.end],
+			Original: s.redaction.Original,
+		})
+	}
+
+	return edits
+}
+
+// ApplyEdits undoes a redaction run. It fails when the content no longer
+// matches what the redaction wrote, e.g. when the file was edited since.
+func ApplyEdits(content string, edits []Edit) (string, error) {
+	sorted := make([]Edit, len(edits))
+	copy(sorted, edits)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Offset > sorted[j].Offset
+	})
+
+	for _, e := range sorted {
+		end := e.Offset + len(e.Redacted)
+		if e.Offset < 0 || end > len(content) || content[e.Offset:end] != e.Redacted {
+			return "", fmt.Errorf("expected %q at offset %d, the file changed since it was redacted", e.Redacted, e.Offset)
+		}
+		content = content[:e.Offset] + e.Original + content[end:]
+	}
+
+	return content, nil
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactTranscriptRestore(t *testing.T) {
+	conversation := "> Please answer part one of the following questions.\n```\n" +
+		strings.Split(puzzle, "--- Part Two ---")[0] +
+		"\n```\n\n● Bash(cd /home/ian/fun/aoc && go run .)\n  ⎿  Part One: 1052\n\n" +
+		"● The answer is 1052, the puzzle asked \"What is the actual password to open the door?\"\n"
+
+	p := NewPuzzleShingles(puzzle)
+	r := Redactor{DayNum: 1, Answers: []string{"1052", "elves"}, Puzzle: &p, Numbered: true}
+
+	redacted, restore := r.RedactTranscript(conversation)
+
+	for _, leaked := range []string{"1052", "/home/ian", "Elves"} {
+		if strings.Contains(redacted, leaked) {
+			t.Errorf("expected %q to be redacted, got:\n%s", leaked, redacted)
+		}
+	}
+
+	if !strings.Contains(redacted, "Part One: (REDACTED#") {
+		t.Errorf("expected numbered placeholders, got:\n%s", redacted)
+	}
+
+	for _, redaction := range restore.Redactions {
+		line := strings.Split(redacted, "\n")[redaction.Line-1]
+		if !strings.Contains(line[redaction.Column-1:], "(REDACTED#") && !strings.HasPrefix(line[redaction.Column-1:], "~") {
+			t.Errorf("redaction %d does not point at a placeholder: %q", redaction.ID, line)
+		}
+	}
+
+	original, err := ApplyEdits(redacted, restore.Edits)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if original != conversation {
+		t.Errorf("restore did not give back the original.\n\nExpecting:\n%s\n\nGot:\n%s", conversation, original)
+	}
+}
+
+func TestRedactJSONLRestore(t *testing.T) {
+	r := Redactor{DayNum: 1, Answers: []string{"1234", "5678"}, Numbered: true}
+
+	redacted, restore, err := r.RedactJSONL(sessionLog)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(restore.Redactions) == 0 {
+		t.Fatalf("expected redactions to be recorded")
+	}
+
+	original, err := ApplyEdits(redacted, restore.Edits)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if original != sessionLog {
+		t.Errorf("restore did not give back the original.\n\nExpecting:\n%s\n\nGot:\n%s", sessionLog, original)
+	}
+}
+
+func TestApplyEditsChangedFile(t *testing.T) {
+	_, err := ApplyEdits("Part One: 12", []Edit{{Offset: 10, Redacted: "(REDACTED#1)", Original: "1052"}})
+	if err == nil {
+		t.Errorf("expected an error when the file no longer matches")
+	}
+}
@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SaltFile holds the secret the hashes of a manifest are salted with. Answers
+// are short numbers, a hash anyone can reproduce would give them away.
+const SaltFile = ".redaction-salt"
+
+// Manifest is the audit trail of a redaction run, dayNN/ai/redaction.json. It
+// says what was removed and why, but never holds the removed text.
+type Manifest struct {
+	Day int `json:"day"`
+	// Policy is the hash of the day's policy, to tell if the conversations
+	// were redacted with the current one
+	Policy string `json:"policy"`
+	// Salt identifies the salt of the hashes, not the salt itself
+	Salt  string         `json:"salt"`
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a redacted conversation as it was written.
+type ManifestFile struct {
+	Path string `json:"path"`
+	// SHA256 of the redacted file, to tell if it was edited since
+	SHA256       string          `json:"sha256"`
+	Replacements []ManifestEntry `json:"replacements"`
+}
+
+// ManifestEntry is one replacement. Line and Column point at the placeholder
+// in the redacted file, for JSONL logs the column is within the JSON string.
+type ManifestEntry struct {
+	Rule   string `json:"rule"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Length int    `json:"length"`
+	Hash   string `json:"hash"`
+}
+
+// ManifestPath returns where the manifest of a day is written.
+func ManifestPath(dayNum int) string {
+	return filepath.Join(fmt.Sprintf("day%02d", dayNum), "ai", "redaction.json")
+}
+
+// LoadSalt reads the redaction salt, creating it the first time.
+func LoadSalt() ([]byte, error) {
+	salt, err := os.ReadFile(SaltFile)
+	if err == nil {
+		return salt, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	salt = make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	salt = []byte(hex.EncodeToString(salt))
+
+	return salt, os.WriteFile(SaltFile, salt, 0600)
+}
+
+// NewManifest starts the manifest of a day redacted with the given policy.
+func NewManifest(dayNum int, policy Policy, salt []byte) (Manifest, error) {
+	policyHash, err := policy.Hash()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	id := sha256.Sum256(salt)
+	return Manifest{Day: dayNum, Policy: policyHash, Salt: hex.EncodeToString(id[:4]), Files: []ManifestFile{}}, nil
+}
+
+// Add records a redacted file and its redactions.
+func (m *Manifest) Add(path string, redacted string, redactions []Redaction, salt []byte) {
+	file := ManifestFile{
+		Path:         filepath.ToSlash(path),
+		SHA256:       sha256Hex([]byte(redacted)),
+		Replacements: []ManifestEntry{},
+	}
+
+	for _, r := range redactions {
+		mac := hmac.New(sha256.New, salt)
+		mac.Write([]byte(r.Original))
+
+		file.Replacements = append(file.Replacements, ManifestEntry{
+			Rule:   r.Rule,
+			Line:   r.Line,
+			Column: r.Column,
+			Length: len(r.Original),
+			Hash:   hex.EncodeToString(mac.Sum(nil)),
+		})
+	}
+
+	m.Files = append(m.Files, file)
+}
+
+// Write saves the manifest next to the conversations of the day.
+func (m Manifest) Write() error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(ManifestPath(m.Day), append(b, '\n'), 0644)
+}
+
+// ReadManifest reads the manifest of a day.
+func ReadManifest(dayNum int) (Manifest, error) {
+	var m Manifest
+
+	b, err := os.ReadFile(ManifestPath(dayNum))
+	if err != nil {
+		return m, err
+	}
+
+	err = json.Unmarshal(b, &m)
+	return m, err
+}
+
+// Check compares the manifest with the files on disk and the current policy
+// and returns what no longer matches.
+func (m Manifest) Check(policy Policy, readFile func(path string) ([]byte, error)) []string {
+	var problems []string
+
+	policyHash, err := policy.Hash()
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if m.Policy != policyHash {
+		problems = append(problems, "redacted with a different policy, run `aoc redact` again")
+	}
+
+	for _, file := range m.Files {
+		content, err := readFile(file.Path)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", file.Path, err))
+			continue
+		}
+
+		if sha256Hex(content) != file.SHA256 {
+			problems = append(problems, fmt.Sprintf("%s: changed since it was redacted", file.Path))
+		}
+	}
+
+	return problems
+}
+
+// Hash identifies a policy, the same rules in the same order give the same
+// hash.
+func (p Policy) Hash() (string, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	return "sha256:" + sha256Hex(b), nil
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
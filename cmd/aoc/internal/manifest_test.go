@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestManifest(t *testing.T) {
+	salt := []byte("secret")
+	policy := DefaultPolicy()
+
+	r := Redactor{DayNum: 1, Answers: []string{"1052"}, Numbered: true}
+	redacted, restore := r.RedactTranscript("> what is it\n\n● It is 1052, I'm in /home/ian/aoc\n")
+
+	m, err := NewManifest(1, policy, salt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.Add("day01/ai/day01_conversation.txt", redacted, restore.Redactions, salt)
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, leaked := range []string{"1052", "/home/ian", "secret"} {
+		if strings.Contains(string(b), leaked) {
+			t.Errorf("expected manifest not to hold %q, got: %s", leaked, b)
+		}
+	}
+
+	entries := m.Files[0].Replacements
+	if len(entries) != 2 || entries[0].Rule != "answer" || entries[0].Length != 4 || entries[0].Line != 3 || entries[0].Column != 11 {
+		t.Errorf("unexpected replacements: %+v", entries)
+	}
+
+	// the same text under another salt must not give the same hash
+	other, _ := NewManifest(1, policy, []byte("other"))
+	other.Add("day01/ai/day01_conversation.txt", redacted, restore.Redactions, []byte("other"))
+	if other.Files[0].Replacements[0].Hash == entries[0].Hash || other.Salt == m.Salt {
+		t.Errorf("expected hashes to depend on the salt")
+	}
+
+	readFile := func(content string) func(string) ([]byte, error) {
+		return func(string) ([]byte, error) {
+			return []byte(content), nil
+		}
+	}
+
+	if problems := m.Check(policy, readFile(redacted)); len(problems) != 0 {
+		t.Errorf("expected no problems, got: %v", problems)
+	}
+
+	if problems := m.Check(policy, readFile(redacted+"edited")); len(problems) != 1 {
+		t.Errorf("expected the edit to be reported, got: %v", problems)
+	}
+
+	changed := policy
+	changed.Allow = []string{"2025"}
+	if problems := m.Check(changed, readFile(redacted)); len(problems) != 1 {
+		t.Errorf("expected the policy change to be reported, got: %v", problems)
+	}
+
+	if problems := m.Check(policy, func(string) ([]byte, error) { return nil, os.ErrNotExist }); len(problems) != 1 {
+		t.Errorf("expected the missing file to be reported, got: %v", problems)
+	}
+}
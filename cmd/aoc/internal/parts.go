@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	partOneMention = regexp.MustCompile(`(?i)\bpart (?:one|1)\b`)
+	partTwoMention = regexp.MustCompile(`(?i)\bpart (?:two|2)\b`)
+)
+
+// partResolver works out which part of the puzzle a pasted block is. In order
+// of trust it looks at:
+//
+//  1. the "--- Part Two ---" or "--- Day N: ... ---" heading in the block
+//  2. similarity to the part one and part two text, when we fetched the puzzle
+//  3. the prompt around the block saying "part one" or "part two"
+//  4. the order of the prompts: the first one with a puzzle is part one, the
+//     second part two
+type partResolver struct {
+	puzzle *PuzzleShingles
+	warn   func(format string, args ...any)
+
+	// number of prompts with a puzzle block seen so far
+	prompts int
+	counted bool
+	line    int
+}
+
+func newPartResolver(puzzle *PuzzleShingles, warn func(format string, args ...any)) *partResolver {
+	if warn == nil {
+		warn = func(string, ...any) {}
+	}
+
+	return &partResolver{puzzle: puzzle, warn: warn}
+}
+
+// newPrompt tells the resolver the following blocks come from a new prompt
+// (or tool output) starting on the given line.
+func (r *partResolver) newPrompt(line int) {
+	r.counted = false
+	r.line = line
+}
+
+// resolve returns the part of a puzzle block found in the given prompt.
+func (r *partResolver) resolve(block string, prompt string) int {
+	if !r.counted {
+		r.prompts++
+		r.counted = true
+	}
+
+	// the heading is as good as it gets
+	if strings.Contains(block, "--- Part Two ---") {
+		return 2
+	}
+	if strings.Contains(block, "--- Day ") {
+		return 1
+	}
+
+	if r.puzzle != nil {
+		if part, ok := r.puzzle.Part(block); ok {
+			return part
+		}
+	}
+
+	// what the prompt asks for, ignoring the pasted text itself
+	instructions := strings.Replace(prompt, block, "", 1)
+	one := partOneMention.MatchString(instructions)
+	two := partTwoMention.MatchString(instructions)
+	switch {
+	case one && !two:
+		return 1
+	case two && !one:
+		return 2
+	}
+
+	switch r.prompts {
+	case 1:
+		return 1
+	case 2:
+		return 2
+	}
+
+	r.warn("line %d: can't tell which part the puzzle block is, labelled it part two", r.line)
+	return 2
+}
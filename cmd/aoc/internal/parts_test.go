@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const (
+	partOneBlock = "The Elves have good news and bad news. For example, suppose the attached document contained the following rotations."
+	partTwoBlock = "You're sure that's the right password, but the door won't open. How many times does the dial point at 0 during any rotation?"
+)
+
+func fenced(s string) string {
+	return "```\n" + s + "\n```\n\n● Okay.\n\n"
+}
+
+func TestRedactPuzzleBlocksParts(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+		warnings int
+	}{
+		{
+			name: "in order",
+			content: "> Please answer part one of the following questions.\n" + fenced(partOneBlock) +
+				"> now do part two\n" + fenced(partTwoBlock),
+			expected: []string{"one", "two"},
+		},
+		{
+			name: "part one pasted twice",
+			content: "> Please answer part one of the following questions.\n" + fenced(partOneBlock) +
+				"> I meant part one, here it is again\n" + fenced(partOneBlock) +
+				"> now do part two\n" + fenced(partTwoBlock),
+			expected: []string{"one", "one", "two"},
+		},
+		{
+			name: "headings win over order",
+			content: "> Here is the puzzle\n" + fenced("--- Part Two ---\n"+partTwoBlock) +
+				"> And this\n" + fenced("--- Day 1: Secret Entrance ---\n"+partOneBlock),
+			expected: []string{"two", "one"},
+		},
+		{
+			name: "third block without hints",
+			content: "> Here is the puzzle\n" + fenced(partOneBlock) +
+				"> Next\n" + fenced(partTwoBlock) +
+				"> And again\n" + fenced(partTwoBlock),
+			expected: []string{"one", "two", "two"},
+			warnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := 0
+			r := Redactor{DayNum: 1, Warnf: func(string, ...any) { warnings++ }}
+
+			got, _ := r.RedactTranscript(tt.content)
+
+			for _, part := range []string{"one", "two"} {
+				expected := 0
+				for _, e := range tt.expected {
+					if e == part {
+						expected++
+					}
+				}
+
+				placeholder := fmt.Sprintf("the puzzle, part %s of advent of code 2025 day 01", part)
+				if count := strings.Count(got, placeholder); count != expected {
+					t.Errorf("expected %d blocks labelled part %s, got %d:\n%s", expected, part, count, got)
+				}
+			}
+
+			if warnings != tt.warnings {
+				t.Errorf("expected %d warnings, got %d", tt.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestRedactPuzzleTextParts(t *testing.T) {
+	partOne := "The Elves have good news and bad news. The good news is that they've\ndiscovered project management! The bad news is that the safe has a dial."
+	partTwo := "You're sure that's the right password, but the door won't open. You knock,\nbut nobody answers. Count the number of times any click causes the dial to\npoint at 0, regardless of whether it happens during a rotation."
+	// as much of one part as of the other, similarity can't tell
+	mixed := partOne + "\nYou're sure that's the right password, but the door won't open. You knock,\nbut nobody answers."
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+		warnings int
+	}{
+		{
+			name: "similarity over order",
+			content: "> Here is the puzzle\n" + fenced(partTwo) +
+				"> And this\n" + fenced(partOne),
+			expected: []string{"two", "one"},
+		},
+		{
+			name:     "heading over similarity",
+			content:  "> Here is the puzzle\n" + fenced("--- Part Two ---\n"+partOne),
+			expected: []string{"two"},
+		},
+		{
+			name: "prompt when similarity can't tell",
+			content: "> Here is the puzzle\n" + fenced(partOne) +
+				"> I pasted it wrong, here is part two\n" + fenced(mixed),
+			expected: []string{"one", "two"},
+		},
+		{
+			name: "order as a last resort",
+			content: "> Here is the puzzle\n" + fenced(mixed) +
+				"> Next\n" + fenced(mixed) +
+				"> And again\n" + fenced(mixed),
+			expected: []string{"one", "two", "two"},
+			warnings: 1,
+		},
+	}
+
+	p := NewPuzzleShingles(puzzle)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := 0
+			r := Redactor{DayNum: 1, Puzzle: &p, Warnf: func(string, ...any) { warnings++ }}
+
+			got, _ := r.RedactTranscript(tt.content)
+
+			labels := regexp.MustCompile(`part (one|two) of advent of code`).FindAllStringSubmatch(got, -1)
+			var parts []string
+			for _, m := range labels {
+				parts = append(parts, m[1])
+			}
+			if strings.Join(parts, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected parts %v, got %v:\n%s", tt.expected, parts, got)
+			}
+
+			if warnings != tt.warnings {
+				t.Errorf("expected %d warnings, got %d", tt.warnings, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PolicyFile is where the redact command looks for the redaction policy.
+const PolicyFile = "redact.json"
+
+// RuleType is what a policy rule looks for.
+type RuleType string
+
+const (
+	// RuleLiteral redacts every occurrence of Text
+	RuleLiteral RuleType = "literal"
+	// RuleRegex redacts every match of Pattern
+	RuleRegex RuleType = "regex"
+	// RuleAnswers redacts the lines of the day's answers file
+	RuleAnswers RuleType = "answers"
+	// RulePuzzleSimilarity redacts the puzzle text, by similarity to the
+	// fetched puzzle or, without it, by guessing from code blocks
+	RulePuzzleSimilarity RuleType = "puzzle-similarity"
+	// RuleInputLines redacts lines of the day's input
+	RuleInputLines RuleType = "input-lines"
+	// RulePrivacy redacts emails and home directories
+	RulePrivacy RuleType = "privacy"
+)
+
+// Scope is the part of a conversation a rule applies to.
+type Scope string
+
+const (
+	ScopeEverywhere Scope = "everywhere"
+	ScopePrompts    Scope = "prompts"
+	ScopeToolOutput Scope = "tool-output"
+)
+
+// Rule is one step of a redaction policy.
+type Rule struct {
+	// Name is used in the restore map and the summary, the type by default
+	Name  string   `json:"name,omitempty"`
+	Type  RuleType `json:"type"`
+	Scope Scope    `json:"scope,omitempty"`
+	// Text of a literal rule
+	Text string `json:"text,omitempty"`
+	// Pattern of a regex rule
+	Pattern string `json:"pattern,omitempty"`
+	// Placeholder replaces the policy's placeholder for this rule only
+	Placeholder string `json:"placeholder,omitempty"`
+
+	regex *regexp.Regexp
+}
+
+// Policy is the content of redact.json. Rules are applied in order, text a
+// rule has already redacted is left alone by the ones after it.
+//
+//	{
+//	  "placeholder": "[removed]",
+//	  "allow": ["2025", "100"],
+//	  "rules": [
+//	    {"type": "answers"},
+//	    {"type": "puzzle-similarity", "scope": "prompts"},
+//	    {"name": "token", "type": "regex", "pattern": "ghp_[A-Za-z0-9]+"},
+//	    {"type": "privacy"}
+//	  ],
+//	  "days": {
+//	    "4": {"disable": ["puzzle-similarity"]}
+//	  }
+//	}
+type Policy struct {
+	// Placeholder is written instead of (REDACTED)
+	Placeholder string `json:"placeholder,omitempty"`
+	// Allow lists text never to redact, like the year or round numbers
+	// that happen to be an answer
+	Allow []string             `json:"allow,omitempty"`
+	Rules []Rule               `json:"rules"`
+	Days  map[string]DayPolicy `json:"days,omitempty"`
+}
+
+// DayPolicy overrides the policy for a single day.
+type DayPolicy struct {
+	Placeholder string   `json:"placeholder,omitempty"`
+	Allow       []string `json:"allow,omitempty"`
+	// Rules are run after the rules of the policy
+	Rules []Rule `json:"rules,omitempty"`
+	// Disable skips rules of the policy, by name or type
+	Disable []string `json:"disable,omitempty"`
+}
+
+// DefaultPolicy is used when there is no redact.json: answers, then the
+// puzzle, then personal details, everywhere in the conversation.
+func DefaultPolicy() Policy {
+	return Policy{
+		Rules: []Rule{
+			{Type: RuleAnswers, Scope: ScopeEverywhere},
+			{Type: RulePuzzleSimilarity, Scope: ScopeEverywhere},
+			{Type: RulePrivacy, Scope: ScopeEverywhere},
+		},
+	}
+}
+
+// LoadPolicy reads a redaction policy. A missing file gives the default
+// policy.
+func LoadPolicy(path string) (Policy, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return DefaultPolicy(), nil
+	}
+	if err != nil {
+		return Policy{}, err
+	}
+
+	var p Policy
+	if err := json.Unmarshal(b, &p); err != nil {
+		return Policy{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := p.validate(); err != nil {
+		return Policy{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return p, nil
+}
+
+func (p *Policy) validate() error {
+	if err := validateRules(p.Rules); err != nil {
+		return err
+	}
+
+	for day, dp := range p.Days {
+		if _, err := strconv.Atoi(day); err != nil {
+			return fmt.Errorf("days: %q is not a day number", day)
+		}
+		if err := validateRules(dp.Rules); err != nil {
+			return fmt.Errorf("days: %s: %w", day, err)
+		}
+	}
+
+	return nil
+}
+
+func validateRules(rules []Rule) error {
+	for i := range rules {
+		rule := &rules[i]
+
+		switch rule.Scope {
+		case "":
+			rule.Scope = ScopeEverywhere
+		case ScopeEverywhere, ScopePrompts, ScopeToolOutput:
+		default:
+			return fmt.Errorf("rule %d: unknown scope %q, expected %s, %s or %s", i+1, rule.Scope, ScopePrompts, ScopeToolOutput, ScopeEverywhere)
+		}
+
+		switch rule.Type {
+		case RuleLiteral:
+			if rule.Text == "" {
+				return fmt.Errorf("rule %d: literal rule without text", i+1)
+			}
+		case RuleRegex:
+			regex, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return fmt.Errorf("rule %d: %w", i+1, err)
+			}
+			if rule.Pattern == "" || regex.MatchString("") {
+				return fmt.Errorf("rule %d: pattern %q matches empty text", i+1, rule.Pattern)
+			}
+			rule.regex = regex
+		case RuleAnswers, RulePuzzleSimilarity, RuleInputLines, RulePrivacy:
+		default:
+			return fmt.Errorf("rule %d: unknown type %q", i+1, rule.Type)
+		}
+	}
+
+	return nil
+}
+
+// ForDay returns the policy with the overrides of a day applied.
+func (p Policy) ForDay(dayNum int) Policy {
+	day := Policy{
+		Placeholder: p.Placeholder,
+		Allow:       p.Allow,
+	}
+
+	var override DayPolicy
+	for key, dp := range p.Days {
+		if n, err := strconv.Atoi(key); err == nil && n == dayNum {
+			override = dp
+		}
+	}
+
+	for _, rule := range p.Rules {
+		disabled := false
+		for _, name := range override.Disable {
+			if name == rule.name() || name == string(rule.Type) {
+				disabled = true
+			}
+		}
+
+		if !disabled {
+			day.Rules = append(day.Rules, rule)
+		}
+	}
+	day.Rules = append(day.Rules, override.Rules...)
+
+	if override.Placeholder != "" {
+		day.Placeholder = override.Placeholder
+	}
+	day.Allow = append(append([]string{}, day.Allow...), override.Allow...)
+
+	return day
+}
+
+func (rule Rule) name() string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+
+	return string(rule.Type)
+}
+
+// finish applies the parts of the policy shared by every rule to the
+// replacements of one: allowed text is kept, the rule name is recorded and
+// the placeholder text swapped.
+func (p Policy) finish(rule Rule, text string, reps []replacement) []replacement {
+	placeholder := p.Placeholder
+	if rule.Placeholder != "" {
+		placeholder = rule.Placeholder
+	}
+
+	var kept []replacement
+	for _, rep := range reps {
+		if p.allowed(text[rep.start:rep.end]) {
+			continue
+		}
+
+		if rule.Name != "" {
+			rep.rule = rule.Name
+		}
+
+		if placeholder != "" {
+			rep.placeholder = withPlaceholder(rep.placeholder, placeholder)
+		}
+
+		kept = append(kept, rep)
+	}
+
+	return kept
+}
+
+// WithoutAllowed returns the answers with the ones the policy allows left
+// empty, for passes that look for answers outside of the rules, like code.
+func (p Policy) WithoutAllowed(answers []string) []string {
+	kept := make([]string, len(answers))
+	for i, answer := range answers {
+		if !p.allowed(answer) {
+			kept[i] = answer
+		}
+	}
+
+	return kept
+}
+
+func (p Policy) allowed(s string) bool {
+	s = strings.TrimSpace(s)
+	for _, allowed := range p.Allow {
+		if s == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withPlaceholder swaps the leading (REDACTED) of a placeholder for custom
+// text. The tag goes inside closing brackets, [removed#3], so numbered
+// placeholders can still be told apart.
+func withPlaceholder(placeholder func(tag string) string, custom string) func(tag string) string {
+	return func(tag string) string {
+		s := placeholder(tag)
+
+		standard := redactedPlaceholder(tag)
+		if !strings.HasPrefix(s, standard) {
+			return s
+		}
+
+		text := custom + tag
+		if last := custom[len(custom)-1]; last == ')' || last == ']' || last == '>' || last == '}' {
+			text = custom[:len(custom)-1] + tag + custom[len(custom)-1:]
+		}
+
+		return text + s[len(standard):]
+	}
+}
+
+func literalReplacements(content string, literal string, rule string) []replacement {
+	if literal == "" {
+		return nil
+	}
+
+	var reps []replacement
+	for offset := 0; ; {
+		idx := strings.Index(content[offset:], literal)
+		if idx == -1 {
+			break
+		}
+
+		start := offset + idx
+		reps = append(reps, replacement{start: start, end: start + len(literal), rule: rule, placeholder: redactedPlaceholder})
+		offset = start + len(literal)
+	}
+
+	return reps
+}
+
+func regexReplacements(content string, rule Rule) []replacement {
+	regex := rule.regex
+	if regex == nil {
+		regex = regexp.MustCompile(rule.Pattern)
+	}
+
+	var reps []replacement
+	for _, m := range regex.FindAllStringIndex(content, -1) {
+		if m[0] == m[1] {
+			continue
+		}
+		reps = append(reps, replacement{start: m[0], end: m[1], rule: "regex", placeholder: redactedPlaceholder})
+	}
+
+	return reps
+}
+
+// inputReplacements finds lines of the puzzle input. Lines pasted one after
+// the other become a single placeholder.
+func inputReplacements(content string, input []string) []replacement {
+	seen := make(map[string]bool)
+	var found []span
+	for _, line := range input {
+		if len(line) < minInputLineLength || seen[line] {
+			continue
+		}
+		seen[line] = true
+
+		for _, rep := range literalReplacements(content, line, "input") {
+			found = append(found, span{start: rep.start, end: rep.end})
+		}
+	}
+
+	// merge the ones only whitespace apart
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].start < found[j].start
+	})
+	var merged []span
+	for _, s := range found {
+		if n := len(merged); n > 0 && strings.TrimSpace(content[min(merged[n-1].end, s.start):s.start]) == "" {
+			merged[n-1].end = max(merged[n-1].end, s.end)
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	var reps []replacement
+	for _, s := range merged {
+		reps = append(reps, replacement{start: s.start, end: s.end, rule: "input", placeholder: func(tag string) string {
+			return fmt.Sprintf("(REDACTED%s) puzzle input", tag)
+		}})
+	}
+
+	return reps
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const policyConversation = `> The answer was 2025 and my token is ghp_abc123, input line L68R30L99 too
+
+● Bash(go run .)
+  ⎿  Part One: 2025
+     Part Two: 4242
+     token ghp_abc123
+
+● Done, part one is 2025.`
+
+func TestPolicyRules(t *testing.T) {
+	policy := Policy{
+		Placeholder: "[removed]",
+		Rules: []Rule{
+			{Type: RuleAnswers},
+			{Name: "token", Type: RuleRegex, Pattern: `ghp_[a-z0-9]+`, Scope: ScopeToolOutput},
+			{Type: RuleInputLines, Scope: ScopePrompts},
+		},
+		Days: map[string]DayPolicy{
+			"01": {Allow: []string{"2025"}},
+		},
+	}
+	if err := policy.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := Redactor{DayNum: 1, Answers: []string{"2025", "4242"}, Input: []string{"L68R30L99", "L1"}, Policy: &policy, Numbered: true}
+	got, restore := r.RedactTranscript(policyConversation)
+
+	expected := `> The answer was 2025 and my token is ghp_abc123, input line [removed#3] puzzle input too
+
+● Bash(go run .)
+  ⎿  Part One: 2025
+     Part Two: [removed#1]
+     token [removed#2]
+
+● Done, part one is 2025.`
+	if got != expected {
+		t.Errorf("unexpected redaction.\n\nExpecting:\n%s\n\nGot:\n%s", expected, got)
+	}
+
+	rules := map[string]int{}
+	for _, redaction := range restore.Redactions {
+		rules[redaction.Rule]++
+	}
+	if rules["answer"] != 1 || rules["token"] != 1 || rules["input"] != 1 {
+		t.Errorf("unexpected rules in restore map: %v", rules)
+	}
+
+	// other days don't get the allowlist
+	r.DayNum = 2
+	if got, _ := r.RedactTranscript(policyConversation); strings.Contains(got, "2025") {
+		t.Errorf("expected 2025 to be redacted on day 2, got:\n%s", got)
+	}
+}
+
+func TestPolicyDisable(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.Days = map[string]DayPolicy{"4": {Disable: []string{"privacy"}}}
+
+	got := policy.ForDay(4)
+	if len(got.Rules) != 2 || got.Rules[0].Type != RuleAnswers || got.Rules[1].Type != RulePuzzleSimilarity {
+		t.Errorf("expected privacy to be disabled on day 4, got: %+v", got.Rules)
+	}
+
+	if len(policy.ForDay(5).Rules) != 3 {
+		t.Errorf("expected day 5 to keep every rule")
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := LoadPolicy(filepath.Join(dir, "missing.json"))
+	if err != nil || len(p.Rules) != len(DefaultPolicy().Rules) {
+		t.Errorf("expected the default policy for a missing file, got %+v, %v", p, err)
+	}
+
+	tests := map[string]string{
+		"unknown type":  `{"rules": [{"type": "magic"}]}`,
+		"unknown scope": `{"rules": [{"type": "answers", "scope": "assistant"}]}`,
+		"bad regex":     `{"rules": [{"type": "regex", "pattern": "("}]}`,
+		"empty match":   `{"rules": [{"type": "regex", "pattern": "a*"}]}`,
+		"empty literal": `{"rules": [{"type": "literal"}]}`,
+		"bad day":       `{"rules": [], "days": {"four": {}}}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, "redact.json")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := LoadPolicy(path); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestPolicyJSONLScope(t *testing.T) {
+	policy := Policy{Rules: []Rule{{Type: RuleLiteral, Text: "1234", Scope: ScopePrompts}}}
+	r := Redactor{DayNum: 1, Policy: &policy}
+
+	got, _, err := r.RedactJSONL(sessionLog)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 1234 only shows up in a tool result
+	if got != sessionLog {
+		t.Errorf("expected tool output to be out of scope, got:\n%s", got)
+	}
+
+	policy.Rules[0].Scope = ScopeToolOutput
+	if got, _, _ := r.RedactJSONL(sessionLog); !strings.Contains(got, "Part One: (REDACTED)") {
+		t.Errorf("expected tool output to be redacted, got:\n%s", got)
+	}
+}
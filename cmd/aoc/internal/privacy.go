@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	// /home/<user> and /Users/<user> where a path starts, not inside a URL
+	// or another path, the rest of the path is fine to keep
+	homeDirRegex = regexp.MustCompile(`(?:^|[\s"'` + "`" + `(=])(/(?:home|Users)/[^/\s"'` + "`" + `]+)`)
+)
+
+// RedactPrivacy removes personal details the assistant CLI prints as a matter
+// of course: the account email in the banner and the user's home directory in
+// tool calls.
+func RedactPrivacy(content string) string {
+	l := newLedger(false, nil)
+	return l.apply(content, privacyReplacements(content))
+}
+
+func privacyReplacements(content string) []replacement {
+	var reps []replacement
+	for _, m := range emailRegex.FindAllStringIndex(content, -1) {
+		rep := replacement{start: m[0], end: m[1], rule: "privacy", placeholder: redactedPlaceholder}
+		if end, ok := cellEnd(content, m[1]); ok {
+			// the rest of the cell moves left and the padding before its
+			// border grows, "x@y.com's Org   │", so the border doesn't move
+			suffix := strings.TrimRight(content[m[1]:end], " ")
+			rep.end = end
+			rep.width = utf8.RuneCountInString(content[rep.start:end])
+			rep.placeholder = func(tag string) string {
+				return redactedPlaceholder(tag) + suffix
+			}
+		}
+		reps = append(reps, rep)
+	}
+
+	// the home directory becomes ~ so paths stay readable
+	for _, m := range homeDirRegex.FindAllStringSubmatchIndex(content, -1) {
+		reps = append(reps, replacement{start: m[2], end: m[3], rule: "privacy", placeholder: func(string) string {
+			return "~"
+		}})
+	}
+
+	return reps
+}
+
+// cellEnd finds the border closing the cell of a line drawn in a box, like
+// the banner the assistant CLI starts with, that i is in.
+func cellEnd(content string, i int) (int, bool) {
+	line, _, _ := strings.Cut(content[i:], "\n")
+	end := strings.Index(line, "│")
+	if end < 0 {
+		return 0, false
+	}
+
+	return i + end, true
+}
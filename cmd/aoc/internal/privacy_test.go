@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRedactPrivacyBanner(t *testing.T) {
+	banner := "╭─── Claude Code v2.0.56 ──────────────────────────────────────────────────────╮\n" +
+		"│                             │ Tips for getting started                       │\n" +
+		"│      Welcome back Ian!      │ Run /init to create a CLAUDE.md file with ins… │\n" +
+		"│           ▐▛███▜▌           │ Recent activity                                │\n" +
+		"│   Sonnet 4.5 · Claude Pro · someone@example.com's Organization   │           │\n" +
+		"│   ~/fun/aoc/2025/day02/ai   │                                                │\n" +
+		"╰──────────────────────────────────────────────────────────────────────────────╯\n"
+
+	got := RedactPrivacy(banner)
+	if strings.Contains(got, "someone@example.com") {
+		t.Fatalf("expected the email to be redacted, got:\n%s", got)
+	}
+
+	lines, gotLines := strings.Split(banner, "\n"), strings.Split(got, "\n")
+	for i := range lines {
+		if utf8.RuneCountInString(gotLines[i]) != utf8.RuneCountInString(lines[i]) {
+			t.Errorf("line %d moved the border:\n%s\n%s", i, lines[i], gotLines[i])
+		}
+	}
+	if want := "│   Sonnet 4.5 · Claude Pro · (REDACTED)'s Organization"; !strings.HasPrefix(gotLines[4], want) {
+		t.Errorf("expected line to start with %q, got %q", want, gotLines[4])
+	}
+}
+
+func TestRedactPrivacy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "email outside a box",
+			content: "mail me at someone@example.com please",
+			want:    "mail me at (REDACTED) please",
+		},
+		{
+			name:    "home directory",
+			content: "● Bash(cd /home/ian/fun/aoc && go test)\n  ⎿  /Users/ian",
+			want:    "● Bash(cd ~/fun/aoc && go test)\n  ⎿  ~",
+		},
+		{
+			name:    "nothing private",
+			content: "see https://example.com/home/page and ./testdata/Users/list",
+			want:    "see https://example.com/home/page and ./testdata/Users/list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactPrivacy(tt.content); got != tt.want {
+				t.Errorf("RedactPrivacy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
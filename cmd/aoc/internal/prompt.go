@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// PromptTemplate overrides the built-in prompt template when it exists.
+var PromptTemplate = filepath.Join(TemplatesDir, "prompt", "prompt.md.tmpl")
+
+// PromptData is what the prompt template is rendered with.
+type PromptData struct {
+	Day   int
+	Year  int
+	Title string
+	// Part is 1 or 2, Puzzle the text of that part only
+	Part   int
+	Puzzle string
+}
+
+// PuzzlePart returns the text of one part of the fetched puzzle.
+func PuzzlePart(content string, part int) (string, error) {
+	partOne, partTwo, found := strings.Cut(content, "--- Part Two ---")
+
+	switch {
+	case part == 1:
+		return strings.TrimSpace(partOne), nil
+	case part == 2 && found:
+		return strings.TrimSpace("--- Part Two ---" + partTwo), nil
+	case part == 2:
+		return "", fmt.Errorf("part two is not in the fetched puzzle yet, run `aoc fetch` again once part one is solved")
+	}
+
+	return "", fmt.Errorf("part must be 1 or 2")
+}
+
+// PuzzleTitle returns the title of a fetched puzzle.
+func PuzzleTitle(content string) string {
+	m := titleRegex.FindStringSubmatch(content)
+	if m == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(m[1])
+}
+
+// RenderPrompt renders the prompt of a part, with the template at path when
+// it exists and the built-in one otherwise.
+func RenderPrompt(data PromptData, path string) (string, error) {
+	source, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		source, err = builtinTemplates.ReadFile("templates/prompt/prompt.md.tmpl")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).Parse(string(source))
+	if err != nil {
+		return "", fmt.Errorf("parsing prompt template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering prompt template: %w", err)
+	}
+
+	return buf.String(), nil
+}
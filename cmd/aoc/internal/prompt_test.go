@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	partOne, err := PuzzlePart(puzzle, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := RenderPrompt(PromptData{Day: 1, Year: 2025, Part: 1, Puzzle: partOne}, filepath.Join(t.TempDir(), "missing.tmpl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "You are working on day01 of advent of code 2025. Please answer part one of the following questions.\n```\n" + partOne + "\n```\n"
+	if got != expected {
+		t.Errorf("unexpected prompt.\n\nExpecting:\n%s\n\nGot:\n%s", expected, got)
+	}
+
+	partTwo, err := PuzzlePart(puzzle, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "prompt.md.tmpl")
+	if err := os.WriteFile(path, []byte("Day {{.Day}} part {{.Part}}: {{.Title}}\n{{.Puzzle}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = RenderPrompt(PromptData{Day: 1, Title: PuzzleTitle(puzzle), Part: 2, Puzzle: partTwo}, path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := "Day 1 part 2: Secret Entrance\n" + partTwo; got != expected {
+		t.Errorf("unexpected prompt.\n\nExpecting:\n%s\n\nGot:\n%s", expected, got)
+	}
+
+	if _, err := PuzzlePart(partOne, 2); err == nil {
+		t.Errorf("expected an error when part two hasn't been fetched")
+	}
+}
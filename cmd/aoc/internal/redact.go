@@ -1,63 +1,90 @@
 package internal
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 	"regexp"
 	"strings"
+
+	"github.com/IanShearer/aoc/aocanswer"
+	"github.com/IanShearer/aoc/cmd/aoc/internal/transcript"
 )
 
+// ReadAnswers returns every answer of an answers file in either format, the
+// accepted ones and the rejected guesses, which are as much of a spoiler.
 func ReadAnswers(path string) ([]string, error) {
-	file, err := os.Open(path)
+	f, err := aocanswer.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	var answers []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			answers = append(answers, line)
-		}
+
+	return f.Guesses(), nil
+}
+
+// ReadAcceptedAnswers returns the accepted answers of an answers file in
+// either format, by part, empty for a part without one before a part with
+// one.
+func ReadAcceptedAnswers(path string) ([]string, error) {
+	f, err := aocanswer.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
 
-	return answers, scanner.Err()
+	return f.Accepted(), nil
 }
 
 func RedactPuzzleBlocks(content string, dayNum int) string {
+	l := newLedger(false, nil)
+	return l.apply(content, codeBlockTranscriptReplacements(content, dayNum, newPartResolver(nil, nil)))
+}
+
+func codeBlockTranscriptReplacements(content string, dayNum int, parts *partResolver) []replacement {
+	// Puzzle text is only ever pasted by the user or printed by a tool, the
+	// assistant's own messages are left alone
+	var reps []replacement
+	offset := 0
+	for _, b := range transcript.Parse(content).Blocks {
+		text := b.Text()
+		if b.Kind == transcript.Prompt || b.Kind == transcript.ToolOutput {
+			parts.newPrompt(b.Line)
+			for _, rep := range codeBlockReplacements(text, dayNum, parts) {
+				rep.start += offset
+				rep.end += offset
+				reps = append(reps, rep)
+			}
+		}
+		offset += len(text) + 1
+	}
+
+	return reps
+}
+
+func codeBlockReplacements(content string, dayNum int, parts *partResolver) []replacement {
 	// Regular expression to match code blocks
 	codeBlockRegex := regexp.MustCompile("(?s)```\\n(.*?)\\n```")
 
-	partNum := 1
-	alreadyRedactedPattern := regexp.MustCompile(`\(REDACTED\) the text in this box is the puzzle`)
+	alreadyRedactedPattern := regexp.MustCompile(`\(REDACTED(?:#\d+)?\) the text in this box is the puzzle`)
 
-	result := codeBlockRegex.ReplaceAllStringFunc(content, func(match string) string {
+	var reps []replacement
+	for _, m := range codeBlockRegex.FindAllStringSubmatchIndex(content, -1) {
 		// Extract the content between the backticks
-		blockContent := match[4 : len(match)-4] // Remove ``` from start and end
+		blockContent := content[m[2]:m[3]]
 
 		// Check if this block is already redacted
 		if alreadyRedactedPattern.MatchString(blockContent) {
-			return match
+			continue
 		}
 
 		// Check if this looks like a puzzle description
 		if IsPuzzleBlock(blockContent) {
-			partLabel := "one"
-			if partNum == 2 {
-				partLabel = "two"
-			}
-			partNum++
+			label := partLabel(parts.resolve(blockContent, content))
 
-			return fmt.Sprintf("```\n(REDACTED) the text in this box is the puzzle, part %s of advent of code 2025 day %02d\n```", partLabel, dayNum)
+			reps = append(reps, replacement{start: m[2], end: m[3], rule: "puzzle", placeholder: func(tag string) string {
+				return fmt.Sprintf("(REDACTED%s) the text in this box is the puzzle, part %s of advent of code 2025 day %02d", tag, label, dayNum)
+			}})
 		}
+	}
 
-		return match
-	})
-
-	return result
+	return reps
 }
 
 func IsPuzzleBlock(content string) bool {
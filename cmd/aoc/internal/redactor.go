@@ -0,0 +1,253 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal/transcript"
+)
+
+// Redactor holds everything needed to redact the conversations of a day.
+type Redactor struct {
+	DayNum  int
+	Answers []string
+	// Puzzle is the fetched puzzle statement. When nil we fall back to
+	// guessing puzzle text from code blocks.
+	Puzzle *PuzzleShingles
+	// Input is the day's puzzle input, one line per entry, for input-lines
+	// rules.
+	Input []string
+	// Policy decides which rules run, in which order and where. When nil
+	// the default policy is used.
+	Policy *Policy
+	// Numbered placeholders, (REDACTED#3), so they can be looked up in the
+	// restore map.
+	Numbered bool
+	// Warnf is told about anything the redactor had to guess, like which
+	// part a pasted puzzle is. May be nil.
+	Warnf func(format string, args ...any)
+}
+
+// RestoreMap is everything needed to undo the redaction of a file. It holds
+// the original text and must never be committed.
+type RestoreMap struct {
+	Path       string      `json:"path"`
+	Redactions []Redaction `json:"redactions"`
+	Edits      []Edit      `json:"edits"`
+}
+
+// Redact redacts a conversation, pasted transcript or JSONL session log.
+func (r Redactor) Redact(content string) (string, RestoreMap, error) {
+	if IsJSONL(content) {
+		return r.RedactJSONL(content)
+	}
+
+	redacted, restore := r.RedactTranscript(content)
+	return redacted, restore, nil
+}
+
+// RedactTranscript redacts a pasted transcript.
+func (r Redactor) RedactTranscript(content string) (string, RestoreMap) {
+	l := newLedger(r.Numbered, nil)
+	parts := newPartResolver(r.Puzzle, r.Warnf)
+
+	policy := r.policy()
+	redacted := content
+	for _, rule := range policy.Rules {
+		redacted = r.applyRule(l, policy, rule, redacted, true, transcriptScope, parts)
+	}
+
+	return redacted, RestoreMap{
+		Redactions: l.redactions(redacted),
+		Edits:      l.edits(redacted, 0),
+	}
+}
+
+// RedactJSONL redacts every string value of a JSONL session log. Each string
+// is a message, a tool input or a tool result, so it is redacted as a piece
+// of text on its own.
+func (r Redactor) RedactJSONL(content string) (string, RestoreMap, error) {
+	var restore RestoreMap
+	ids := 0
+	parts := newPartResolver(r.Puzzle, r.Warnf)
+	policy := r.policy()
+	lines := strings.Split(content, "\n")
+	redacted, edits, err := RewriteJSONLStrings(content, func(s string, line int) string {
+		l := newLedger(r.Numbered, &ids)
+		parts.newPrompt(line)
+
+		scope := jsonlScope(jsonlLineScope(lines[line-1]))
+		for _, rule := range policy.Rules {
+			s = r.applyRule(l, policy, rule, s, false, scope, parts)
+		}
+
+		// positions inside a JSON string don't map to the file, point at the
+		// line of the log and leave the column relative to the string
+		for _, redaction := range l.redactions(s) {
+			redaction.Line = line
+			restore.Redactions = append(restore.Redactions, redaction)
+		}
+
+		return s
+	})
+	if err != nil {
+		return "", RestoreMap{}, err
+	}
+
+	// the file is restored one JSON string at a time, so escapes come back
+	// exactly as they were
+	restore.Edits = edits
+	return redacted, restore, nil
+}
+
+func (r Redactor) policy() Policy {
+	if r.Policy == nil {
+		return DefaultPolicy()
+	}
+
+	return r.Policy.ForDay(r.DayNum)
+}
+
+// scopeFilter keeps the replacements that fall in the scope of a rule.
+type scopeFilter func(text string, scope Scope, reps []replacement) []replacement
+
+// applyRule runs one rule of the policy over text. A transcript is searched
+// block by block where it matters, a JSONL string is a piece of text on its
+// own.
+func (r Redactor) applyRule(l *ledger, policy Policy, rule Rule, text string, isTranscript bool, inScope scopeFilter, parts *partResolver) string {
+	apply := func(text string, reps []replacement) string {
+		return l.apply(text, policy.finish(rule, text, inScope(text, rule.Scope, reps)))
+	}
+
+	switch rule.Type {
+	case RuleAnswers:
+		// one pass per answer, so a longer answer isn't cut up by a shorter
+		// one found inside it
+		for _, answer := range r.Answers {
+			if answer == "" {
+				continue
+			}
+			text = apply(text, literalReplacements(text, answer, "answer"))
+		}
+		return text
+	case RuleLiteral:
+		return apply(text, literalReplacements(text, rule.Text, "literal"))
+	case RuleRegex:
+		return apply(text, regexReplacements(text, rule))
+	case RulePuzzleSimilarity:
+		switch {
+		case r.Puzzle != nil && isTranscript:
+			return apply(text, r.Puzzle.transcriptReplacements(text, r.DayNum, parts))
+		case r.Puzzle != nil:
+			return apply(text, r.Puzzle.replacements(text, r.DayNum, false, parts))
+		case isTranscript:
+			return apply(text, codeBlockTranscriptReplacements(text, r.DayNum, parts))
+		default:
+			return apply(text, codeBlockReplacements(text, r.DayNum, parts))
+		}
+	case RuleInputLines:
+		return apply(text, inputReplacements(text, r.Input))
+	case RulePrivacy:
+		return apply(text, privacyReplacements(text))
+	}
+
+	return text
+}
+
+// transcriptScope keeps the replacements starting in a block of the kind the
+// scope asks for.
+func transcriptScope(text string, scope Scope, reps []replacement) []replacement {
+	if scope == ScopeEverywhere || scope == "" || len(reps) == 0 {
+		return reps
+	}
+
+	kind := transcript.Prompt
+	if scope == ScopeToolOutput {
+		kind = transcript.ToolOutput
+	}
+
+	var blocks []span
+	offset := 0
+	for _, b := range transcript.Parse(text).Blocks {
+		length := len(b.Text())
+		if b.Kind == kind {
+			blocks = append(blocks, span{start: offset, end: offset + length})
+		}
+		offset += length + 1
+	}
+
+	var kept []replacement
+	for _, rep := range reps {
+		for _, b := range blocks {
+			if rep.start >= b.start && rep.start < b.end {
+				kept = append(kept, rep)
+				break
+			}
+		}
+	}
+
+	return kept
+}
+
+// jsonlScope keeps every replacement of a string when it is in scope, and
+// none otherwise.
+func jsonlScope(stringScope Scope) scopeFilter {
+	return func(text string, scope Scope, reps []replacement) []replacement {
+		if scope == ScopeEverywhere || scope == "" || scope == stringScope {
+			return reps
+		}
+
+		return nil
+	}
+}
+
+// jsonlLineScope tells whether a line of a session log is a user prompt or
+// the output of a tool. Anything else, the assistant's own messages and
+// tool calls, is neither.
+func jsonlLineScope(line string) Scope {
+	var entry struct {
+		Type    string `json:"type"`
+		Message struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.Type != "user" {
+		return ""
+	}
+
+	// tool results come back to the model as user messages
+	if bytes.Contains(entry.Message.Content, []byte(`"tool_result"`)) {
+		return ScopeToolOutput
+	}
+
+	return ScopePrompts
+}
+
+// ConversationPath is where the pasted conversation of a day's AI solution
+// is.
+func ConversationPath(dayNum int) string {
+	return filepath.Join(fmt.Sprintf("day%02d", dayNum), "ai", fmt.Sprintf("day%02d_conversation.txt", dayNum))
+}
+
+// ConversationFiles returns the conversations committed for a day: the
+// pasted dayNN_conversation.txt and any raw .jsonl session logs.
+func ConversationFiles(dayNum int) ([]string, error) {
+	aiDir := filepath.Join(fmt.Sprintf("day%02d", dayNum), "ai")
+
+	var files []string
+	transcriptPath := ConversationPath(dayNum)
+	if _, err := os.Stat(transcriptPath); err == nil {
+		files = append(files, transcriptPath)
+	}
+
+	logs, err := filepath.Glob(filepath.Join(aiDir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(files, logs...), nil
+}
@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	gotoken "go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// RegistryFile registers every Go solution's puzzle package with aocsolver.
+// It is generated, aoc create writes it again for each new day.
+var RegistryFile = filepath.Join("solvers", "registry.go")
+
+var moduleRegex = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+
+// RegistryEntry is a puzzle package in the registry.
+type RegistryEntry struct {
+	Day     int
+	Variant string
+	// Path is the package's directory, relative to the module
+	Path string
+	// Alias is what it is imported as, e.g. day04human
+	Alias string
+	// Parts is the kind of PartOne and PartTwo functions it has, so they can
+	// be timed apart
+	Parts PartsKind
+}
+
+// PartsKind is the kind of PartOne and PartTwo functions of a puzzle
+// package.
+type PartsKind int
+
+const (
+	NoParts PartsKind = iota
+	// IntParts are func(input string) of an integer, as aoc create
+	// scaffolds them
+	IntParts
+	// AnswerParts are aocsolver.PartFunc, like Solve for a single part
+	AnswerParts
+)
+
+// Solver is the aocsolver.Solver the entry is registered as.
+func (e RegistryEntry) Solver() string {
+	solve := fmt.Sprintf("aocsolver.SolverFunc(%s.Solve)", e.Alias)
+	switch e.Parts {
+	case IntParts:
+		return fmt.Sprintf("aocsolver.WithParts(%s, aocsolver.IntPart(%s.PartOne), aocsolver.IntPart(%s.PartTwo))", solve, e.Alias, e.Alias)
+	case AnswerParts:
+		return fmt.Sprintf("aocsolver.WithParts(%s, %s.PartOne, %s.PartTwo)", solve, e.Alias, e.Alias)
+	}
+	return solve
+}
+
+// ModulePath returns the module path of the go.mod in the current directory.
+func ModulePath() (string, error) {
+	b, err := os.ReadFile("go.mod")
+	if err != nil {
+		return "", err
+	}
+
+	m := moduleRegex.FindSubmatch(b)
+	if m == nil {
+		return "", fmt.Errorf("go.mod has no module line")
+	}
+
+	return string(m[1]), nil
+}
+
+// RegistryEntries returns the dayNN/<variant>/puzzle packages, by day and
+// then variant.
+func RegistryEntries() ([]RegistryEntry, error) {
+	dirs, err := filepath.Glob(filepath.Join("day[0-9][0-9]", "*", "puzzle"))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []RegistryEntry
+	for _, dir := range dirs {
+		goFiles, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		if len(goFiles) == 0 {
+			continue
+		}
+
+		partsKind, err := partFuncs(goFiles)
+		if err != nil {
+			return nil, err
+		}
+
+		var day int
+		parts := strings.Split(filepath.ToSlash(dir), "/")
+		if _, err := fmt.Sscanf(parts[0], "day%02d", &day); err != nil {
+			continue
+		}
+
+		entries = append(entries, RegistryEntry{
+			Day:     day,
+			Variant: parts[1],
+			Path:    filepath.ToSlash(dir),
+			Alias:   parts[0] + parts[1],
+			Parts:   partsKind,
+		})
+	}
+
+	return entries, nil
+}
+
+// partFuncs returns the kind of PartOne and PartTwo functions the Go files
+// declare, NoParts unless both are of the same kind.
+func partFuncs(goFiles []string) (PartsKind, error) {
+	found := make(map[string]PartsKind)
+	fset := gotoken.NewFileSet()
+	for _, path := range goFiles {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return NoParts, err
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || (fn.Name.Name != "PartOne" && fn.Name.Name != "PartTwo") {
+				continue
+			}
+			found[fn.Name.Name] = partKind(fn.Type)
+		}
+	}
+
+	if found["PartOne"] != found["PartTwo"] {
+		return NoParts, nil
+	}
+	return found["PartOne"], nil
+}
+
+// partKind returns the kind of part function of a signature.
+func partKind(fn *ast.FuncType) PartsKind {
+	param := oneField(fn.Params)
+	switch {
+	case isIdent(param, "string") && isIdent(oneField(fn.Results), integerTypes...):
+		return IntParts
+	case isByteSlice(param) && fn.Results != nil && len(fn.Results.List) == 2:
+		answer, ok := fn.Results.List[0].Type.(*ast.SelectorExpr)
+		if ok && answer.Sel.Name == "Answer" && isIdent(fn.Results.List[1].Type, "error") {
+			return AnswerParts
+		}
+	}
+	return NoParts
+}
+
+func isByteSlice(expr ast.Expr) bool {
+	slice, ok := expr.(*ast.ArrayType)
+	return ok && slice.Len == nil && isIdent(slice.Elt, "byte")
+}
+
+var integerTypes = []string{"int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64"}
+
+// oneField returns the type of a list of a single parameter or result.
+func oneField(fields *ast.FieldList) ast.Expr {
+	if fields == nil || len(fields.List) != 1 || len(fields.List[0].Names) > 1 {
+		return nil
+	}
+	return fields.List[0].Type
+}
+
+func isIdent(expr ast.Expr, names ...string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && slices.Contains(names, ident.Name)
+}
+
+// RenderRegistry renders the registry of the entries.
+func RenderRegistry(module string, entries []RegistryEntry) ([]byte, error) {
+	source, err := builtinTemplates.ReadFile("templates/registry/registry.go.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("registry.go.tmpl").Parse(string(source))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	data := struct {
+		Module  string
+		Solvers []RegistryEntry
+	}{module, entries}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
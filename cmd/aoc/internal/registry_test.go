@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderRegistry(t *testing.T) {
+	entries := []RegistryEntry{
+		{Day: 4, Variant: "ai", Path: "day04/ai/puzzle", Alias: "day04ai"},
+		{Day: 4, Variant: "human", Path: "day04/human/puzzle", Alias: "day04human"},
+		{Day: 5, Variant: "ai", Path: "day05/ai/puzzle", Alias: "day05ai", Parts: IntParts},
+		{Day: 5, Variant: "human", Path: "day05/human/puzzle", Alias: "day05human", Parts: AnswerParts},
+	}
+
+	b, err := RenderRegistry("example.com/aoc", entries)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"// Code generated by aoc create",
+		`day04human "example.com/aoc/day04/human/puzzle"`,
+		`aocsolver.Register(4, "ai", aocsolver.SolverFunc(day04ai.Solve))`,
+		`aocsolver.Register(5, "ai", aocsolver.WithParts(aocsolver.SolverFunc(day05ai.Solve), aocsolver.IntPart(day05ai.PartOne), aocsolver.IntPart(day05ai.PartTwo)))`,
+		`aocsolver.Register(5, "human", aocsolver.WithParts(aocsolver.SolverFunc(day05human.Solve), day05human.PartOne, day05human.PartTwo))`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected the registry to contain %q, got:\n%s", want, b)
+		}
+	}
+}
+
+func TestPartFuncs(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   PartsKind
+	}{
+		{"scaffolded", "func PartOne(input string) int { return 0 }\nfunc PartTwo(input string) int64 { return 0 }", IntParts},
+		{"answers", "func PartOne(input []byte) (aocsolver.Answer, error) { return \"\", nil }\nfunc PartTwo(input []byte) (aocsolver.Answer, error) { return \"\", nil }", AnswerParts},
+		{"mixed", "func PartOne(input string) int { return 0 }\nfunc PartTwo(input []byte) (aocsolver.Answer, error) { return \"\", nil }", NoParts},
+		{"only part one", "func PartOne(input string) int { return 0 }", NoParts},
+		{"string answer", "func PartOne(input string) string { return \"\" }\nfunc PartTwo(input string) int { return 0 }", NoParts},
+		{"bytes", "func PartOne(input []byte) int { return 0 }\nfunc PartTwo(input []byte) int { return 0 }", NoParts},
+		{"methods", "type P struct{}\nfunc (P) PartOne(input string) int { return 0 }\nfunc (P) PartTwo(input string) int { return 0 }", NoParts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "puzzle.go")
+			if err := os.WriteFile(path, []byte("package puzzle\n\n"+tt.source+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := partFuncs([]string{path})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("partFuncs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
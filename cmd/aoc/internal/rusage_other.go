@@ -0,0 +1,10 @@
+//go:build !unix
+
+package internal
+
+import "os"
+
+// maxRSS is unknown outside of unix.
+func maxRSS(state *os.ProcessState) int64 {
+	return 0
+}
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// TemplatesDir is where templates overriding the built-in ones live, in a
+// subdirectory per language. A template named go/main.go.tmpl there replaces
+// the built-in go/main.go.tmpl, any other *.tmpl is rendered as an extra
+// file. The prompt template lives in its prompt subdirectory.
+const TemplatesDir = "templates"
+
+//go:embed templates
+var builtinTemplates embed.FS
+
+// TemplateData is what scaffolding templates are rendered with.
+type TemplateData struct {
+	Day   int
+	Year  int
+	Title string
+	// Module is the Go module path, Variant the solution directory, "ai" or
+	// "human", so Go solutions can import their puzzle package
+	Module  string
+	Variant string
+	// Examples from the puzzle, wired into the generated tests
+	Examples []Example
+}
+
+var templateFuncs = template.FuncMap{
+	// literal quotes text as a Go string literal, raw when it can be
+	"literal": func(s string) string {
+		if !strings.Contains(s, "`") && !strings.Contains(s, "\r") {
+			return "`" + s + "`"
+		}
+		return strconv.Quote(s)
+	},
+	// rustLiteral quotes text as a Rust raw string literal
+	"rustLiteral": func(s string) string {
+		hashes := "#"
+		for strings.Contains(s, "\""+hashes) {
+			hashes += "#"
+		}
+		return "r" + hashes + "\"" + s + "\"" + hashes
+	},
+	"inc": func(i int) int {
+		return i + 1
+	},
+}
+
+// RenderScaffold renders the templates of a solution directory in a language
+// and returns the content of each file by slash separated path. Go files are
+// gofmt'ed.
+func RenderScaffold(lang Language, data TemplateData, overrideDir string) (map[string][]byte, error) {
+	sources, err := scaffoldTemplates(lang.Name, overrideDir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for name, source := range sources {
+		tmpl, err := template.New(name).Funcs(templateFuncs).Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("parsing template %s: %w", name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("rendering template %s: %w", name, err)
+		}
+
+		file := strings.TrimSuffix(name, ".tmpl")
+		content := buf.Bytes()
+		if strings.HasSuffix(file, ".go") {
+			content, err = format.Source(content)
+			if err != nil {
+				return nil, fmt.Errorf("template %s does not render valid Go: %w", name, err)
+			}
+		}
+		files[file] = content
+	}
+
+	return files, nil
+}
+
+// ScaffoldFileNames returns the names of the rendered files in a stable
+// order.
+func ScaffoldFileNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// scaffoldTemplates returns the built-in templates of a language with the
+// ones of overrideDir/<language> on top, by path.
+func scaffoldTemplates(lang, overrideDir string) (map[string]string, error) {
+	sources := make(map[string]string)
+
+	builtin, err := fs.Sub(builtinTemplates, "templates/"+lang)
+	if err != nil {
+		return nil, err
+	}
+	if err := readTemplates(builtin, sources); err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no templates for language %s", lang)
+	}
+
+	override := filepath.Join(overrideDir, lang)
+	if _, err := os.Stat(override); err == nil {
+		if err := readTemplates(os.DirFS(override), sources); err != nil {
+			return nil, err
+		}
+	}
+
+	return sources, nil
+}
+
+// readTemplates adds every *.tmpl under fsys to sources, by path.
+func readTemplates(fsys fs.FS, sources map[string]string) error {
+	return fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".tmpl") {
+			return nil
+		}
+
+		b, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		sources[path] = string(b)
+		return nil
+	})
+}
@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const puzzleHTML = `<main>
+<article class="day-desc"><h2>--- Day 1: Secret Entrance ---</h2><p>The Elves have good news.</p>
+<p>For example, suppose the attached document contained the following rotations:</p>
+<pre><code>L68
+L30
+R48
+</code></pre>
+<p>Because the dial points at <code>0</code> a total of three times, the password in this example is <code><em>3</em></code>.</p>
+</article>
+<p>Your puzzle answer was <code>1052</code>.</p>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2>
+<p>Following the same rotations as in the above example, the dial points at zero a few extra times, so the new password would be <code><em>6</em></code>.</p>
+</article>
+</main>`
+
+func TestExtractExamples(t *testing.T) {
+	if title := ExtractPuzzleTitle(puzzleHTML); title != "Secret Entrance" {
+		t.Errorf("unexpected title %q", title)
+	}
+
+	examples := ExtractExamples(puzzleHTML)
+	if len(examples) != 1 {
+		t.Fatalf("expected 1 example, got %+v", examples)
+	}
+
+	expected := Example{Input: "L68\nL30\nR48", PartOne: "3", PartTwo: "6"}
+	if examples[0] != expected {
+		t.Errorf("unexpected example.\n\nExpecting: %+v\nGot: %+v", expected, examples[0])
+	}
+}
+
+func TestRenderScaffold(t *testing.T) {
+	data := TemplateData{Day: 1, Year: 2025, Title: "Secret Entrance", Module: "example.com/aoc", Variant: "human", Examples: ExtractExamples(puzzleHTML)}
+
+	golang, err := LookupLanguage("go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := RenderScaffold(golang, data, filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if names := strings.Join(ScaffoldFileNames(files), ","); names != "main.go,puzzle/puzzle.go,puzzle/puzzle_test.go" {
+		t.Errorf("unexpected files %s", names)
+	}
+
+	for name, want := range map[string][]string{
+		"main.go": {
+			"// Day 1: Secret Entrance, Advent of Code 2025",
+			`"example.com/aoc/day01/human/puzzle"`,
+			"aocsolver.Main(aocsolver.WithParts(aocsolver.SolverFunc(puzzle.Solve), aocsolver.IntPart(puzzle.PartOne), aocsolver.IntPart(puzzle.PartTwo)))",
+		},
+		"puzzle/puzzle.go": {
+			"func PartOne(input string) int {",
+			"func Solve(input []byte) (aocsolver.Answer, aocsolver.Answer, error) {",
+		},
+		"puzzle/puzzle_test.go": {
+			"{\"example 1\", `L68\nL30\nR48`, 3},",
+			"{\"example 1\", `L68\nL30\nR48`, 6},",
+		},
+	} {
+		for _, w := range want {
+			if !strings.Contains(string(files[name]), w) {
+				t.Errorf("expected %s to contain %q, got:\n%s", name, w, files[name])
+			}
+		}
+	}
+
+	// templates of the override directory replace the built-in ones by name
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "go"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go", "main.go.tmpl"), []byte("package main\n\n// day {{printf \"%02d\" .Day}} of {{.Year}}\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go", "NOTES.md.tmpl"), []byte("# {{.Title}}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = RenderScaffold(golang, data, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := string(files["main.go"]); got != "package main\n\n// day 01 of 2025\nfunc main() {}\n" {
+		t.Errorf("expected the override to be used, got:\n%s", got)
+	}
+	if got := string(files["NOTES.md"]); got != "# Secret Entrance\n" {
+		t.Errorf("expected the extra template to be rendered, got:\n%s", got)
+	}
+	if _, ok := files["puzzle/puzzle_test.go"]; !ok {
+		t.Errorf("expected the built-in puzzle/puzzle_test.go to be kept")
+	}
+}
+
+func TestRenderRustScaffold(t *testing.T) {
+	data := TemplateData{Day: 1, Year: 2025, Title: "Secret Entrance", Examples: []Example{{Input: "say \"#hi\"", PartOne: "3"}}}
+
+	rust, err := LookupLanguage("rust")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := RenderScaffold(rust, data, filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if names := strings.Join(ScaffoldFileNames(files), ","); names != "Cargo.toml,src/main.rs" {
+		t.Errorf("unexpected files %s", names)
+	}
+
+	for name, want := range map[string][]string{
+		"Cargo.toml": {`name = "day01"`, `name = "solution"`},
+		"src/main.rs": {
+			`fs::read_to_string("../input")`,
+			`println!("Part One: {}", part_one(input));`,
+			`("example 1", r##"say "#hi""##, 3),`,
+		},
+	} {
+		for _, w := range want {
+			if !strings.Contains(string(files[name]), w) {
+				t.Errorf("expected %s to contain %q, got:\n%s", name, w, files[name])
+			}
+		}
+	}
+
+	if _, err := LookupLanguage("cobol"); err == nil {
+		t.Errorf("expected an unknown language to be an error")
+	}
+}
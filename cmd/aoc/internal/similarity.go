@@ -0,0 +1,363 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal/transcript"
+)
+
+const (
+	// number of words in a shingle
+	shingleSize = 6
+	// number of shingles a region needs to share with the puzzle before we
+	// consider it puzzle text
+	minSharedShingles = 4
+	// number of unmatched words allowed inside a region
+	maxGap = 4
+)
+
+type token struct {
+	word       string
+	start, end int
+}
+
+// tokenize splits text into lower cased words made of letters and digits,
+// remembering where each word is in the original text.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start == -1 {
+			start = i
+		} else if !isWord && start != -1 {
+			tokens = append(tokens, token{strings.ToLower(text[start:i]), start, i})
+			start = -1
+		}
+	}
+
+	if start != -1 {
+		tokens = append(tokens, token{strings.ToLower(text[start:]), start, len(text)})
+	}
+
+	return tokens
+}
+
+// isProse tells if a shingle reads like a sentence. Example inputs (rotations,
+// ranges, digit strings) are also in the puzzle statement but show up in tests
+// all the time, we don't want to redact those.
+func isProse(tokens []token) bool {
+	words := 0
+	for _, t := range tokens {
+		alpha := true
+		for _, r := range t.word {
+			if !unicode.IsLetter(r) {
+				alpha = false
+				break
+			}
+		}
+
+		if alpha {
+			words++
+		}
+	}
+
+	return words*2 > len(tokens)
+}
+
+func shingleKey(tokens []token) string {
+	words := make([]string, len(tokens))
+	for i, t := range tokens {
+		words[i] = t.word
+	}
+
+	return strings.Join(words, " ")
+}
+
+// PuzzleShingles is the set of word shingles of a fetched puzzle statement.
+type PuzzleShingles struct {
+	// shingle to the index of its first word in the puzzle
+	shingles map[string]int
+	// index of the first word of the part two statement, -1 if the puzzle
+	// only has part one
+	partTwo int
+}
+
+func NewPuzzleShingles(puzzle string) PuzzleShingles {
+	p := PuzzleShingles{shingles: make(map[string]int), partTwo: -1}
+
+	if idx := strings.Index(puzzle, "--- Part Two ---"); idx != -1 {
+		p.partTwo = len(tokenize(puzzle[:idx]))
+	}
+
+	tokens := tokenize(puzzle)
+	for i := 0; i+shingleSize <= len(tokens); i++ {
+		window := tokens[i : i+shingleSize]
+		if !isProse(window) {
+			continue
+		}
+
+		key := shingleKey(window)
+		if _, ok := p.shingles[key]; !ok {
+			p.shingles[key] = i
+		}
+	}
+
+	return p
+}
+
+// PuzzleSpan is a region of text that was copied from the puzzle statement.
+type PuzzleSpan struct {
+	Start int
+	End   int
+	Part  int
+}
+
+// Find returns the regions of text sharing enough shingles with the puzzle,
+// in order and without overlaps.
+func (p PuzzleShingles) Find(text string) []PuzzleSpan {
+	tokens := tokenize(text)
+
+	var spans []PuzzleSpan
+	first, last := -1, -1
+	shared, partTwo := 0, 0
+
+	flush := func() {
+		if first != -1 && shared >= minSharedShingles {
+			part := 1
+			if partTwo*2 > shared {
+				part = 2
+			}
+			spans = append(spans, PuzzleSpan{
+				Start: tokens[first].start,
+				End:   tokens[last].end,
+				Part:  part,
+			})
+		}
+		first, last = -1, -1
+		shared, partTwo = 0, 0
+	}
+
+	for i := 0; i+shingleSize <= len(tokens); i++ {
+		pos, ok := p.shingles[shingleKey(tokens[i:i+shingleSize])]
+		if !ok {
+			continue
+		}
+
+		// a few changed words (a typo, a paraphrase) should not split a region
+		if first != -1 && i > last+1+maxGap {
+			flush()
+		}
+
+		if first == -1 {
+			first = i
+		}
+		last = i + shingleSize - 1
+		shared++
+		if p.partTwo != -1 && pos >= p.partTwo {
+			partTwo++
+		}
+	}
+	flush()
+
+	// swallow the punctuation around the first and last word on the same line,
+	// so we don't leave a lonely "---" or "?" behind
+	for i := range spans {
+		for j := spans[i].Start; j > 0 && (isOpening(text[j-1]) || text[j-1] == ' '); j-- {
+			if isOpening(text[j-1]) {
+				spans[i].Start = j - 1
+			}
+		}
+		for j := spans[i].End; j < len(text) && (isClosing(text[j]) || text[j] == ' '); j++ {
+			if isClosing(text[j]) {
+				spans[i].End = j + 1
+			}
+		}
+	}
+
+	return spans
+}
+
+// Part tells which part of the puzzle a piece of text was copied from. It is
+// only sure when the text clearly leans one way.
+func (p PuzzleShingles) Part(text string) (int, bool) {
+	tokens := tokenize(text)
+
+	one, two := 0, 0
+	for i := 0; i+shingleSize <= len(tokens); i++ {
+		pos, ok := p.shingles[shingleKey(tokens[i:i+shingleSize])]
+		if !ok {
+			continue
+		}
+
+		if p.partTwo != -1 && pos >= p.partTwo {
+			two++
+		} else {
+			one++
+		}
+	}
+
+	switch {
+	case one+two < minSharedShingles:
+		return 0, false
+	case one > 2*two:
+		return 1, true
+	case two > 2*one:
+		return 2, true
+	}
+
+	return 0, false
+}
+
+func isOpening(b byte) bool {
+	return strings.IndexByte("-'\"", b) != -1
+}
+
+func isClosing(b byte) bool {
+	return strings.IndexByte("-?!.,:;'\"", b) != -1
+}
+
+// RedactPuzzleText replaces every part of the conversation that was copied
+// from the puzzle statement, fenced or not.
+func RedactPuzzleText(content string, puzzle string, dayNum int) string {
+	p := NewPuzzleShingles(puzzle)
+
+	l := newLedger(false, nil)
+	return l.apply(content, p.transcriptReplacements(content, dayNum, newPartResolver(&p, nil)))
+}
+
+// transcriptReplacements finds the puzzle text in every block of a
+// transcript, the banner aside. What a prompt pastes is all puzzle, so the
+// regions found in one are joined. Prompts and tool outputs count towards
+// the order of pasted parts, the assistant quoting the puzzle doesn't.
+func (p PuzzleShingles) transcriptReplacements(content string, dayNum int, parts *partResolver) []replacement {
+	var reps []replacement
+	offset := 0
+	for _, b := range transcript.Parse(content).Blocks {
+		text := b.Text()
+		if b.Kind == transcript.Prompt || b.Kind == transcript.ToolOutput {
+			parts.newPrompt(b.Line)
+		}
+		if b.Kind != transcript.Header {
+			for _, rep := range p.replacements(text, dayNum, b.Kind == transcript.Prompt, parts) {
+				rep.start += offset
+				rep.end += offset
+				reps = append(reps, rep)
+			}
+		}
+		offset += len(text) + 1
+	}
+
+	return reps
+}
+
+// replacements finds the puzzle text in a piece of text. Regions of the same
+// part within a fenced block, or anywhere in the text when whole is set, are
+// joined: the example input between them is puzzle too, it is just not prose.
+// Which part a region is comes from the resolver.
+func (p PuzzleShingles) replacements(text string, dayNum int, whole bool, parts *partResolver) []replacement {
+	regions := fencedRegions(text)
+	if whole {
+		regions = append(regions, span{start: 0, end: len(text)})
+	}
+
+	var reps []replacement
+	for _, s := range joinSpans(p.Find(text), regions) {
+		s.Start = withHeading(text, s.Start)
+
+		// when the span is a whole fenced block use the same wording as the
+		// heuristic redaction does
+		if isWholeFence(text, s) {
+			s.Start = strings.LastIndex(text[:s.Start], "```") + len("```\n")
+			s.End = strings.Index(text[s.End:], "```") + s.End - len("\n")
+			label := partLabel(parts.resolve(text[s.Start:s.End], text))
+			reps = append(reps, replacement{start: s.Start, end: s.End, rule: "puzzle", placeholder: func(tag string) string {
+				return fmt.Sprintf("(REDACTED%s) the text in this box is the puzzle, part %s of advent of code 2025 day %02d", tag, label, dayNum)
+			}})
+			continue
+		}
+
+		label := partLabel(parts.resolve(text[s.Start:s.End], text))
+		reps = append(reps, replacement{start: s.Start, end: s.End, rule: "puzzle", placeholder: func(tag string) string {
+			return fmt.Sprintf("(REDACTED%s) puzzle text, part %s of advent of code 2025 day %02d", tag, label, dayNum)
+		}})
+	}
+
+	return reps
+}
+
+// withHeading moves the start of a span to the "--- Part Two ---" or
+// "--- Day N: ... ---" heading on the line above, if there is one. It isn't
+// prose but it is the puzzle, and tells which part it is.
+func withHeading(text string, start int) int {
+	before := strings.TrimRight(text[:start], " \n")
+	lineStart := strings.LastIndex(before, "\n") + 1
+	line := strings.TrimSpace(before[lineStart:])
+	if len(line) > len("--- ---") && strings.HasPrefix(line, "--- ") && strings.HasSuffix(line, " ---") {
+		return lineStart
+	}
+
+	return start
+}
+
+// joinSpans joins consecutive spans of the same part that start in the same
+// region.
+func joinSpans(spans []PuzzleSpan, regions []span) []PuzzleSpan {
+	var joined []PuzzleSpan
+	for _, s := range spans {
+		if n := len(joined); n > 0 && joined[n-1].Part == s.Part && sameRegion(regions, joined[n-1].Start, s.Start) {
+			joined[n-1].End = s.End
+			continue
+		}
+		joined = append(joined, s)
+	}
+
+	return joined
+}
+
+func sameRegion(regions []span, a, b int) bool {
+	for _, r := range regions {
+		if a >= r.start && a < r.end && b >= r.start && b < r.end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fencedRegions returns where the content of every fenced block of text is.
+func fencedRegions(text string) []span {
+	var regions []span
+	open := -1
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, " "), "```") {
+			if open == -1 {
+				open = offset + len(line)
+			} else {
+				regions = append(regions, span{start: open, end: offset})
+				open = -1
+			}
+		}
+		offset += len(line)
+	}
+
+	return regions
+}
+
+func isWholeFence(text string, s PuzzleSpan) bool {
+	before := strings.TrimRight(text[:s.Start], " \n")
+	after := strings.TrimLeft(text[s.End:], " \n")
+	return strings.HasSuffix(before, "\n```") && strings.HasPrefix(after, "```") &&
+		strings.HasPrefix(text[len(before):], "\n") && strings.HasSuffix(text[:len(text)-len(after)], "\n")
+}
+
+func partLabel(part int) string {
+	if part == 2 {
+		return "two"
+	}
+
+	return "one"
+}
@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+const puzzle = `--- Day 1: Secret Entrance ---
+The Elves have good news and bad news. The good news is that they've
+discovered project management! The bad news is that the safe has a dial.
+
+For example, suppose the attached document contained the following rotations:
+
+L68
+L30
+R48
+
+What is the actual password to open the door?
+
+--- Part Two ---
+You're sure that's the right password, but the door won't open. You knock,
+but nobody answers. Count the number of times any click causes the dial to
+point at 0, regardless of whether it happens during a rotation.`
+
+func TestRedactPuzzleTextFenced(t *testing.T) {
+	conversation := "> Please answer part one of the following questions.\n```\n" +
+		strings.Split(puzzle, "--- Part Two ---")[0] +
+		"\n```\n\n● I'll solve this. The test input is L68 L30 R48.\n"
+
+	got := RedactPuzzleText(conversation, puzzle, 1)
+	expected := "> Please answer part one of the following questions.\n```\n" +
+		"(REDACTED) the text in this box is the puzzle, part one of advent of code 2025 day 01\n" +
+		"```\n\n● I'll solve this. The test input is L68 L30 R48.\n"
+
+	if got != expected {
+		t.Errorf("unexpected redaction.\n\nExpecting:\n%s\n\nGot:\n%s", expected, got)
+	}
+}
+
+func TestRedactPuzzleTextExample(t *testing.T) {
+	// a real example is long enough to split the prose around it in two
+	longer := strings.Replace(puzzle, "L68\nL30\nR48\n", "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\n", 1)
+	partOne := strings.Split(longer, "--- Part Two ---")[0]
+
+	tests := []struct {
+		name         string
+		conversation string
+		expected     string
+	}{
+		{
+			name:         "fenced",
+			conversation: "> Please answer part one of the following questions.\n```\n" + partOne + "\n```\n",
+			expected: "> Please answer part one of the following questions.\n```\n" +
+				"(REDACTED) the text in this box is the puzzle, part one of advent of code 2025 day 01\n```\n",
+		},
+		{
+			name:         "unfenced prompt",
+			conversation: "> Please answer part one of the following questions.\n\n" + partOne + "\n● I'll solve this.\n",
+			expected: "> Please answer part one of the following questions.\n\n" +
+				"(REDACTED) puzzle text, part one of advent of code 2025 day 01\n\n\n● I'll solve this.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RedactPuzzleText(tt.conversation, longer, 1)
+			if got != tt.expected {
+				t.Errorf("unexpected redaction.\n\nExpecting:\n%s\n\nGot:\n%s", tt.expected, got)
+			}
+			if strings.Contains(got, "R14") {
+				t.Errorf("the example input was left in:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestRedactPuzzleTextUnfenced(t *testing.T) {
+	conversation := "> now do part two\n\n" +
+		"● The puzzle says: \"Count the number of times any click causes the dial\n" +
+		"  to point at 0, regardless of whether it happens during a rotation.\" Let\n" +
+		"  me update the solution.\n"
+
+	got := RedactPuzzleText(conversation, puzzle, 1)
+	expected := "> now do part two\n\n" +
+		"● The puzzle says: (REDACTED) puzzle text, part two of advent of code 2025 day 01 Let\n" +
+		"  me update the solution.\n"
+
+	if got != expected {
+		t.Errorf("unexpected redaction.\n\nExpecting:\n%s\n\nGot:\n%s", expected, got)
+	}
+}
+
+func TestRedactPuzzleTextLeavesProseAlone(t *testing.T) {
+	conversation := "● The dial has positions 0-99 and wraps around. I count how many times\n" +
+		"  the dial lands on 0 after each rotation.\n"
+
+	if got := RedactPuzzleText(conversation, puzzle, 1); got != conversation {
+		t.Errorf("expected assistant prose to be left alone, got:\n%s", got)
+	}
+}
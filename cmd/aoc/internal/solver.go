@@ -0,0 +1,315 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SolverNames are the directories a day's solutions live in.
+var SolverNames = []string{"ai", "human"}
+
+// answerLineRegex matches the lines solutions print their answers on, be it
+// with Printf("Part One: %d\n") or Println("Part One:", x)
+var answerLineRegex = regexp.MustCompile(`^\s*Part (One|Two)\s*:\s*(.*?)\s*$`)
+
+// Solver is one solution of a day.
+type Solver struct {
+	Day      int
+	Name     string
+	Dir      string
+	Language Language
+	// Timeout kills runs taking longer, when set
+	Timeout time.Duration
+}
+
+// RunResult is what a solver printed and what it took.
+type RunResult struct {
+	// Answers are by part, empty when the part wasn't printed
+	Answers [2]string
+	// Times are from the start of the run until each answer was printed
+	Times [2]time.Duration
+	Wall  time.Duration
+	// MaxRSS is the peak resident set size in bytes, 0 when unknown
+	MaxRSS int64
+	Output string
+	Stderr string
+}
+
+// NewSolver returns the solver of a day by name, "ai" or "human".
+func NewSolver(day int, name string) (*Solver, error) {
+	valid := false
+	for _, n := range SolverNames {
+		valid = valid || n == name
+	}
+	if !valid {
+		return nil, fmt.Errorf("unknown solver %q, expected one of %v", name, SolverNames)
+	}
+
+	dir := filepath.Join(fmt.Sprintf("day%02d", day), name)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("no %s solver for day %d: %w", name, day, err)
+	}
+
+	lang, err := SolverLanguage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Solver{Day: day, Name: name, Dir: dir, Language: lang}, nil
+}
+
+// String names the solver as its directory.
+func (s *Solver) String() string {
+	return filepath.ToSlash(s.Dir)
+}
+
+// InProcess tells if the solver has a puzzle package registered with
+// aocsolver, which can be called without going through its main.
+func (s *Solver) InProcess() bool {
+	_, err := os.Stat(filepath.Join(s.Dir, "puzzle"))
+	return s.Language.Name == "go" && err == nil
+}
+
+// Build builds the solver with its language's build command.
+func (s *Solver) Build() error {
+	if len(s.Language.Build) == 0 {
+		return nil
+	}
+
+	cmd := exec.Command(s.Language.Build[0], s.Language.Build[1:]...)
+	cmd.Dir = s.Dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("building %s: %w\n%s", s, err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// Test runs the solver's tests with its language's test command and returns
+// what they printed.
+func (s *Solver) Test() (string, error) {
+	if len(s.Language.Test) == 0 {
+		return "", nil
+	}
+
+	cmd := exec.Command(s.Language.Test[0], s.Language.Test[1:]...)
+	cmd.Dir = s.Dir
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// Run runs the built solver on the day's input, or on inputPath when it is
+// set, and parses its answers. Solutions read ../input, so they are run from
+// their own directory, or from one next to a copy of inputPath, and are given
+// the input in AOC_INPUT too.
+func (s *Solver) Run(inputPath string) (*RunResult, error) {
+	binary, err := filepath.Abs(filepath.Join(s.Dir, s.Language.Binary))
+	if err != nil {
+		return nil, err
+	}
+
+	// Solutions using aocinput read AOC_INPUT, the others ../input
+	workDir := s.Dir
+	if inputPath == "" {
+		inputPath = filepath.Join(filepath.Dir(s.Dir), "input")
+	} else {
+		tmp, err := os.MkdirTemp("", fmt.Sprintf("aoc-day%02d-", s.Day))
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(tmp)
+
+		if workDir, err = inputWorkDir(tmp, s, inputPath); err != nil {
+			return nil, err
+		}
+	}
+
+	absInput, err := filepath.Abs(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(binary)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(), "AOC_INPUT="+absInput)
+	return s.run(cmd)
+}
+
+// SolveCommand is the package of the binary running every registered solver
+// in-process.
+const SolveCommand = "./cmd/solve"
+
+// BuildSolve builds SolveCommand into dir and returns the binary.
+func BuildSolve(dir string) (string, error) {
+	binary := filepath.Join(dir, "solve")
+	out, err := exec.Command("go", "build", "-o", binary, SolveCommand).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("building %s: %w\n%s", SolveCommand, err, strings.TrimSpace(string(out)))
+	}
+
+	return binary, nil
+}
+
+// RunInProcess runs the solver's registered puzzle package with the solve
+// binary built by BuildSolve, on the day's input or on inputPath when it is
+// set.
+func (s *Solver) RunInProcess(solveBinary, inputPath string) (*RunResult, error) {
+	if inputPath == "" {
+		inputPath = filepath.Join(filepath.Dir(s.Dir), "input")
+	}
+
+	cmd := exec.Command(solveBinary, "-input", inputPath, strconv.Itoa(s.Day), s.Name)
+	return s.run(cmd)
+}
+
+// run runs cmd and parses the answers it prints as it prints them.
+func (s *Solver) run(cmd *exec.Cmd) (*RunResult, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RunResult{}
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("running %s: %w", s, err)
+	}
+
+	var timer *time.Timer
+	if s.Timeout > 0 {
+		timer = time.AfterFunc(s.Timeout, func() { cmd.Process.Kill() })
+	}
+
+	var output strings.Builder
+	reader := bufio.NewReader(stdout)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if line != "" {
+			output.WriteString(line)
+			if part, answer, ok := ParseAnswerLine(line); ok {
+				result.Answers[part-1] = answer
+				result.Times[part-1] = time.Since(start)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			// Stop the solver and reap it rather than leave a zombie
+			cmd.Process.Kill()
+			cmd.Wait()
+			if timer != nil {
+				timer.Stop()
+			}
+			return nil, fmt.Errorf("reading the output of %s: %w", s, readErr)
+		}
+	}
+
+	err = cmd.Wait()
+	result.Wall = time.Since(start)
+	result.MaxRSS = maxRSS(cmd.ProcessState)
+	result.Output = output.String()
+	result.Stderr = stderr.String()
+
+	if timer != nil && !timer.Stop() {
+		return result, fmt.Errorf("%s timed out after %v", s, s.Timeout)
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return result, fmt.Errorf("%s exited with %d: %s", s, exitErr.ExitCode(), firstLine(result.Stderr))
+	}
+
+	return result, err
+}
+
+// ParseAnswerLine returns the part and answer of a "Part One: X" or
+// "Part Two: Y" line.
+func ParseAnswerLine(line string) (int, string, bool) {
+	m := answerLineRegex.FindStringSubmatch(line)
+	if m == nil {
+		return 0, "", false
+	}
+
+	if m[1] == "Two" {
+		return 2, m[2], true
+	}
+	return 1, m[2], true
+}
+
+// Statuses of a part checked against the answers file.
+const (
+	StatusPass    = "pass"
+	StatusFail    = "fail"
+	StatusMissing = "missing"
+)
+
+// CheckAnswer compares the answer a solver printed for a part with the
+// answers read by ReadAnswers. It is missing when either is unknown.
+func CheckAnswer(answers []string, part int, got string) string {
+	if part > len(answers) || answers[part-1] == "" || got == "" {
+		return StatusMissing
+	}
+	if answers[part-1] != got {
+		return StatusFail
+	}
+
+	return StatusPass
+}
+
+// inputWorkDir lays out tmp like a day directory with inputPath as its
+// input, and returns the solver directory in it.
+func inputWorkDir(tmp string, s *Solver, inputPath string) (string, error) {
+	input, err := os.ReadFile(inputPath)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "input"), input, 0644); err != nil {
+		return "", err
+	}
+
+	// aocanswer looks for the answers next to the input
+	answers, err := os.ReadFile(filepath.Join(filepath.Dir(s.Dir), "answers"))
+	if err == nil {
+		if err := os.WriteFile(filepath.Join(tmp, "answers"), answers, 0644); err != nil {
+			return "", err
+		}
+	}
+
+	dir := filepath.Join(tmp, s.Name)
+	return dir, os.Mkdir(dir, 0755)
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+// FormatBytes formats a size in bytes for humans.
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
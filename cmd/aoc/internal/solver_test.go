@@ -0,0 +1,59 @@
+package internal
+
+import "testing"
+
+func TestParseAnswerLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		part   int
+		answer string
+		ok     bool
+	}{
+		{"Part One: 1234\n", 1, "1234", true},
+		{"Part Two: 5678", 2, "5678", true},
+		// fmt.Println("Part One:", x) and friends
+		{"Part One:  42 \r\n", 1, "42", true},
+		{"Part Two :abc", 2, "abc", true},
+		{"Reading input...", 0, "", false},
+		{"Part Three: 1", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		part, answer, ok := ParseAnswerLine(tt.line)
+		if part != tt.part || answer != tt.answer || ok != tt.ok {
+			t.Errorf("ParseAnswerLine(%q) = %d, %q, %v, expected %d, %q, %v", tt.line, part, answer, ok, tt.part, tt.answer, tt.ok)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{
+		512:              "512 B",
+		10 * 1024:        "10.0 KiB",
+		10*1024*1024 + 1: "10.0 MiB",
+	} {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, expected %q", n, got, want)
+		}
+	}
+}
+
+func TestCheckAnswer(t *testing.T) {
+	answers := []string{"1234"}
+
+	for _, tt := range []struct {
+		part int
+		got  string
+		want string
+	}{
+		{1, "1234", StatusPass},
+		{1, "1235", StatusFail},
+		{1, "", StatusMissing},
+		// part two isn't in the answers file yet
+		{2, "5678", StatusMissing},
+	} {
+		if got := CheckAnswer(answers, tt.part, tt.got); got != tt.want {
+			t.Errorf("CheckAnswer(%v, %d, %q) = %s, expected %s", answers, tt.part, tt.got, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"os"
+	"regexp"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal/transcript"
+)
+
+// ToolKinds are the tools counted on their own in Stats, anything else is
+// counted as other.
+var ToolKinds = []string{"Read", "Write", "Update", "Bash", "Search"}
+
+var (
+	testCommand = regexp.MustCompile(`\b(?:go|cargo) test\b`)
+	testFailure = regexp.MustCompile(`(?m)^(?:FAIL\b|--- FAIL|Error: Exit code)|\btest result: FAILED\b`)
+)
+
+// Stats is how the assistant went about a day, counted from its
+// conversation.
+type Stats struct {
+	Day     int    `json:"day,omitempty"`
+	Version string `json:"version,omitempty"`
+	Model   string `json:"model,omitempty"`
+	// Turns are the user prompts of each part.
+	Turns [2]int         `json:"turns"`
+	Tools map[string]int `json:"tools"`
+	// Rewrites are writes and updates of a file the assistant already wrote.
+	Rewrites    int `json:"rewrites"`
+	TestRuns    int `json:"test_runs"`
+	FailedTests int `json:"failed_test_runs"`
+}
+
+// ReadStats counts the stats of a day's conversation file.
+func ReadStats(dayNum int) (*Stats, error) {
+	path := ConversationPath(dayNum)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := ParseStats(string(b))
+	stats.Day = dayNum
+	return stats, nil
+}
+
+// ParseStats counts the stats of a conversation. Prompts are part one until
+// one asks for part two.
+func ParseStats(content string) *Stats {
+	t := transcript.Parse(content)
+	stats := &Stats{Tools: map[string]int{}}
+	stats.Version, stats.Model = t.Banner()
+
+	part := 1
+	written := make(map[string]bool)
+	for _, turn := range t.Turns() {
+		if turn.Role == transcript.User {
+			prompt := turn.Blocks[0].Body()
+			if partTwoMention.MatchString(prompt) && !partOneMention.MatchString(prompt) {
+				part = 2
+			}
+			stats.Turns[part-1]++
+			continue
+		}
+
+		for _, inv := range turn.Invocations() {
+			stats.Tools[toolKind(inv.Name)]++
+
+			switch inv.Name {
+			case "Write", "Update":
+				if written[inv.Args] {
+					stats.Rewrites++
+				}
+				written[inv.Args] = true
+			case "Bash":
+				if !testCommand.MatchString(inv.Args) {
+					continue
+				}
+				stats.TestRuns++
+				if inv.Output != nil && testFailure.MatchString(inv.Output.Body()) {
+					stats.FailedTests++
+				}
+			}
+		}
+	}
+
+	return stats
+}
+
+func toolKind(name string) string {
+	for _, kind := range ToolKinds {
+		if name == kind {
+			return kind
+		}
+	}
+
+	return "Other"
+}
+
+// Add adds the counts of other to s, for totals.
+func (s *Stats) Add(other *Stats) {
+	for i := range s.Turns {
+		s.Turns[i] += other.Turns[i]
+	}
+	for kind, n := range other.Tools {
+		s.Tools[kind] += n
+	}
+	s.Rewrites += other.Rewrites
+	s.TestRuns += other.TestRuns
+	s.FailedTests += other.FailedTests
+}
@@ -0,0 +1,85 @@
+package internal
+
+import "testing"
+
+const statsSample = `
+ ▐▛███▜▌   Claude Code v2.0.60
+▝▜█████▛▘  Sonnet 4.5 · Claude Pro
+
+> You are working on day04. Please answer part one of the following.
+
+● Read(main.go)
+  ⎿  Read 20 lines
+
+● Write(main.go)
+  ⎿  Wrote 40 lines to main.go
+
+● Bash(go test -v)
+  ⎿  Error: Exit code 1
+     --- FAIL: TestPartOneSample (0.00s)
+
+● Update(main.go)
+  ⎿  Updated main.go with 1 addition
+
+● Bash(go test -v)
+  ⎿  PASS
+
+● Bash(go run main.go)
+  ⎿  Part One: (REDACTED)
+
+> That's right.
+
+> now do part two
+
+● Search(pattern: "*.go")
+  ⎿  Found 2 files
+
+● Update(main.go)
+  ⎿  Updated main.go with 9 additions
+
+● Write(main_test.go)
+  ⎿  Wrote 30 lines to main_test.go
+
+● Glob(*)
+  ⎿  Found 4 files
+
+● Bash(cd ~/fun/aoc/2025/day04/ai && go test)
+  ⎿  FAIL	github.com/IanShearer/aoc/day04/ai	0.003s
+`
+
+func TestParseStats(t *testing.T) {
+	stats := ParseStats(statsSample)
+
+	if stats.Version != "2.0.60" || stats.Model != "Sonnet 4.5 · Claude Pro" {
+		t.Errorf("unexpected banner: %q %q", stats.Version, stats.Model)
+	}
+
+	if stats.Turns != [2]int{2, 1} {
+		t.Errorf("expected turns [2 1], got %v", stats.Turns)
+	}
+
+	expected := map[string]int{"Read": 1, "Write": 2, "Update": 2, "Bash": 4, "Search": 1, "Other": 1}
+	for kind, n := range expected {
+		if stats.Tools[kind] != n {
+			t.Errorf("expected %d %s calls, got %d", n, kind, stats.Tools[kind])
+		}
+	}
+
+	if stats.Rewrites != 2 {
+		t.Errorf("expected 2 rewrites, got %d", stats.Rewrites)
+	}
+
+	if stats.TestRuns != 3 || stats.FailedTests != 2 {
+		t.Errorf("expected 3 test runs with 2 failed, got %d with %d failed", stats.TestRuns, stats.FailedTests)
+	}
+}
+
+func TestStatsAdd(t *testing.T) {
+	total := &Stats{Tools: map[string]int{}}
+	total.Add(ParseStats(statsSample))
+	total.Add(ParseStats(statsSample))
+
+	if total.Turns != [2]int{4, 2} || total.Tools["Bash"] != 8 || total.Rewrites != 4 || total.FailedTests != 4 {
+		t.Errorf("unexpected totals: %+v", total)
+	}
+}
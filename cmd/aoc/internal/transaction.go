@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"os"
+)
+
+// FileTransaction writes files and directories and remembers what it did,
+// so a failed run can put everything back as it was.
+type FileTransaction struct {
+	// Force overwrites existing files instead of skipping them
+	Force bool
+
+	created     []string
+	overwritten map[string][]byte
+	// Report lists what was done to each path, in order
+	Report []FileAction
+}
+
+// FileAction is what a transaction did to a path.
+type FileAction struct {
+	Path   string
+	Action string // "created", "overwritten" or "skipped"
+}
+
+// Exists tells if a path exists and would be skipped by a write.
+func (t *FileTransaction) Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Mkdir creates a directory unless it exists.
+func (t *FileTransaction) Mkdir(path string) error {
+	if info, err := os.Stat(path); err == nil {
+		if !info.IsDir() {
+			return &os.PathError{Op: "mkdir", Path: path, Err: errors.New("not a directory")}
+		}
+		return nil
+	}
+
+	if err := os.Mkdir(path, 0755); err != nil {
+		return err
+	}
+
+	t.created = append(t.created, path)
+	t.Report = append(t.Report, FileAction{Path: path + "/", Action: "created"})
+	return nil
+}
+
+// WriteFile writes a file unless it exists, or Force is set.
+func (t *FileTransaction) WriteFile(path string, content []byte, perm os.FileMode) error {
+	return t.writeFile(path, content, perm, t.Force)
+}
+
+// CreateFile writes a file only when it doesn't exist, even with Force.
+func (t *FileTransaction) CreateFile(path string, content []byte, perm os.FileMode) error {
+	return t.writeFile(path, content, perm, false)
+}
+
+// ReplaceFile writes a generated file even without Force, and skips it when
+// it already has the content.
+func (t *FileTransaction) ReplaceFile(path string, content []byte, perm os.FileMode) error {
+	if original, err := os.ReadFile(path); err == nil && bytes.Equal(original, content) {
+		t.Report = append(t.Report, FileAction{Path: path, Action: "skipped"})
+		return nil
+	}
+
+	return t.writeFile(path, content, perm, true)
+}
+
+func (t *FileTransaction) writeFile(path string, content []byte, perm os.FileMode, force bool) error {
+	original, err := os.ReadFile(path)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if exists && !force {
+		t.Report = append(t.Report, FileAction{Path: path, Action: "skipped"})
+		return nil
+	}
+
+	if err := os.WriteFile(path, content, perm); err != nil {
+		return err
+	}
+
+	if exists {
+		if t.overwritten == nil {
+			t.overwritten = make(map[string][]byte)
+		}
+		if _, ok := t.overwritten[path]; !ok {
+			t.overwritten[path] = original
+		}
+		t.Report = append(t.Report, FileAction{Path: path, Action: "overwritten"})
+		return nil
+	}
+
+	t.created = append(t.created, path)
+	t.Report = append(t.Report, FileAction{Path: path, Action: "created"})
+	return nil
+}
+
+// Rollback removes what was created, newest first, and puts overwritten
+// files back.
+func (t *FileTransaction) Rollback() error {
+	var errs []error
+	for path, original := range t.overwritten {
+		errs = append(errs, os.WriteFile(path, original, 0644))
+	}
+
+	for i := len(t.created) - 1; i >= 0; i-- {
+		errs = append(errs, os.Remove(t.created[i]))
+	}
+
+	t.created = nil
+	t.overwritten = nil
+	t.Report = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileTransaction(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing")
+	if err := os.WriteFile(existing, []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := &FileTransaction{}
+	newDir := filepath.Join(dir, "day01")
+	newFile := filepath.Join(newDir, "main.go")
+	for _, err := range []error{
+		tx.Mkdir(newDir),
+		tx.WriteFile(newFile, []byte("package main\n"), 0644),
+		tx.WriteFile(existing, []byte("theirs"), 0644),
+	} {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := []FileAction{
+		{Path: newDir + "/", Action: "created"},
+		{Path: newFile, Action: "created"},
+		{Path: existing, Action: "skipped"},
+	}
+	if len(tx.Report) != len(expected) {
+		t.Fatalf("unexpected report: %+v", tx.Report)
+	}
+	for i := range expected {
+		if tx.Report[i] != expected[i] {
+			t.Errorf("unexpected action %d.\n\nExpecting: %+v\nGot: %+v", i, expected[i], tx.Report[i])
+		}
+	}
+
+	tx.Force = true
+	if err := tx.WriteFile(existing, []byte("theirs"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tx.CreateFile(existing, []byte("never"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b, _ := os.ReadFile(existing); string(b) != "theirs" {
+		t.Errorf("expected forced write and no create, got %q", b)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(newDir); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed", newDir)
+	}
+	if b, _ := os.ReadFile(existing); string(b) != "mine" {
+		t.Errorf("expected overwritten file to be put back, got %q", b)
+	}
+}
+
+func TestReplaceFile(t *testing.T) {
+	generated := filepath.Join(t.TempDir(), "registry.go")
+	if err := os.WriteFile(generated, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := &FileTransaction{}
+	for _, err := range []error{
+		tx.ReplaceFile(generated, []byte("new"), 0644),
+		tx.ReplaceFile(generated, []byte("new"), 0644),
+	} {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if b, _ := os.ReadFile(generated); string(b) != "new" {
+		t.Errorf("expected the file to be replaced without Force, got %q", b)
+	}
+	if len(tx.Report) != 2 || tx.Report[0].Action != "overwritten" || tx.Report[1].Action != "skipped" {
+		t.Errorf("expected overwritten then skipped, got %+v", tx.Report)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b, _ := os.ReadFile(generated); string(b) != "old" {
+		t.Errorf("expected the replaced file to be put back, got %q", b)
+	}
+}
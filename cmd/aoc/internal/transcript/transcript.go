@@ -0,0 +1,272 @@
+// Package transcript parses the conversation files we commit next to each
+// AI solution (dayNN/ai/dayNN_conversation.txt) into typed blocks and turns.
+//
+// A transcript is made of:
+//
+//   - a banner header printed by the assistant CLI
+//   - user prompts, starting with "> "
+//   - assistant messages, starting with "● "
+//   - tool calls, starting with "● Name(args)", e.g. "● Bash(go test)"
+//   - tool output, starting with "⎿" underneath a tool call
+//
+// Every block keeps its raw lines, so String() reproduces the original file
+// byte for byte unless a block was edited.
+package transcript
+
+import (
+	"regexp"
+	"strings"
+)
+
+type Kind int
+
+const (
+	Header Kind = iota
+	Prompt
+	Message
+	ToolCall
+	ToolOutput
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Header:
+		return "header"
+	case Prompt:
+		return "prompt"
+	case Message:
+		return "message"
+	case ToolCall:
+		return "tool-call"
+	case ToolOutput:
+		return "tool-output"
+	}
+
+	return "unknown"
+}
+
+const (
+	PromptMarker    = ">"
+	AssistantMarker = "● "
+	OutputMarker    = "⎿"
+	fence           = "```"
+)
+
+var toolCallRegex = regexp.MustCompile(`^● ([A-Z][A-Za-z]*)\((.*)$`)
+
+// Block is a contiguous run of lines belonging to one element of the
+// transcript. Lines are stored exactly as they appear in the file, markers
+// and trailing blank lines included.
+type Block struct {
+	Kind Kind
+	// Line is the 1-based line number of the first line at parse time.
+	Line  int
+	Lines []string
+}
+
+// Text returns the raw lines of the block joined by newlines.
+func (b *Block) Text() string {
+	return strings.Join(b.Lines, "\n")
+}
+
+// SetText replaces the raw lines of the block.
+func (b *Block) SetText(text string) {
+	b.Lines = strings.Split(text, "\n")
+}
+
+// Body returns the block's text with the leading marker and the hanging
+// indentation removed. It is meant for reading, not for writing back.
+func (b *Block) Body() string {
+	lines := make([]string, 0, len(b.Lines))
+	for i, line := range b.Lines {
+		if i == 0 {
+			line = stripMarker(b.Kind, line)
+		} else {
+			line = trimIndent(line, hangingIndent(b.Kind))
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n ")
+}
+
+// Tool returns the tool name and arguments of a ToolCall block, e.g.
+// "Write" and "main.go" for "● Write(main.go)".
+func (b *Block) Tool() (name string, args string, ok bool) {
+	if b.Kind != ToolCall || len(b.Lines) == 0 {
+		return "", "", false
+	}
+
+	m := toolCallRegex.FindStringSubmatch(b.Lines[0])
+	if m == nil {
+		return "", "", false
+	}
+
+	// long calls wrap onto the following lines
+	parts := []string{m[2]}
+	for _, line := range b.Lines[1:] {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			parts = append(parts, line)
+		}
+	}
+
+	args = strings.Join(parts, " ")
+	args = strings.TrimSuffix(strings.TrimSpace(args), ")")
+	return m[1], args, true
+}
+
+func stripMarker(kind Kind, line string) string {
+	switch kind {
+	case Prompt:
+		line = strings.TrimPrefix(line, PromptMarker)
+		return strings.TrimPrefix(line, " ")
+	case Message, ToolCall:
+		return strings.TrimPrefix(line, AssistantMarker)
+	case ToolOutput:
+		line = strings.TrimLeft(line, " ")
+		line = strings.TrimPrefix(line, OutputMarker)
+		return strings.TrimLeft(line, "\u00a0 ")
+	}
+
+	return line
+}
+
+// hangingIndent is the number of spaces the assistant CLI puts in front of
+// wrapped lines of each kind of block.
+func hangingIndent(kind Kind) int {
+	switch kind {
+	case Message, ToolCall:
+		return 2
+	case ToolOutput:
+		return 5
+	}
+
+	return 0
+}
+
+func trimIndent(line string, n int) string {
+	for i := 0; i < n && strings.HasPrefix(line, " "); i++ {
+		line = line[1:]
+	}
+
+	return line
+}
+
+// Transcript is a parsed conversation file.
+type Transcript struct {
+	Blocks []*Block
+}
+
+// Parse splits a conversation into blocks. It never fails: anything it does
+// not recognise is attached to the block before it, or to the header when
+// it appears before the first prompt.
+func Parse(content string) *Transcript {
+	t := &Transcript{}
+
+	var current *Block
+	inFence := false
+	for i, line := range strings.Split(content, "\n") {
+		kind, starts := classify(line, current, inFence)
+		if starts {
+			current = &Block{Kind: kind, Line: i + 1}
+			t.Blocks = append(t.Blocks, current)
+			inFence = false
+		} else if current == nil {
+			current = &Block{Kind: Header, Line: i + 1}
+			t.Blocks = append(t.Blocks, current)
+		}
+
+		// pasted puzzle text lives inside fences in the prompt, never start a
+		// new block from within one
+		if current.Kind == Prompt && strings.HasPrefix(line, fence) {
+			inFence = !inFence
+		}
+
+		current.Lines = append(current.Lines, line)
+	}
+
+	return t
+}
+
+func classify(line string, current *Block, inFence bool) (Kind, bool) {
+	if inFence {
+		return 0, false
+	}
+
+	if line == PromptMarker || strings.HasPrefix(line, PromptMarker+" ") {
+		return Prompt, true
+	}
+
+	if strings.HasPrefix(line, AssistantMarker) {
+		if toolCallRegex.MatchString(line) {
+			return ToolCall, true
+		}
+		return Message, true
+	}
+
+	if current != nil && current.Kind == ToolCall && strings.HasPrefix(strings.TrimLeft(line, " "), OutputMarker) {
+		return ToolOutput, true
+	}
+
+	return 0, false
+}
+
+// String writes the transcript back out. For an unmodified transcript the
+// result is identical to the input given to Parse.
+func (t *Transcript) String() string {
+	var sb strings.Builder
+	for i, b := range t.Blocks {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(b.Text())
+	}
+
+	return sb.String()
+}
+
+// Filter returns the blocks of the given kinds in file order.
+func (t *Transcript) Filter(kinds ...Kind) []*Block {
+	var blocks []*Block
+	for _, b := range t.Blocks {
+		for _, k := range kinds {
+			if b.Kind == k {
+				blocks = append(blocks, b)
+				break
+			}
+		}
+	}
+
+	return blocks
+}
+
+var (
+	versionRegex = regexp.MustCompile(`Claude Code v(\S+)`)
+	bannerCell   = regexp.MustCompile(`[│╭╮╰╯─\x{2580}-\x{259F}]`)
+)
+
+// Banner returns the CLI version and the model line, e.g. "2.0.56" and
+// "Sonnet 4.5 · Claude Pro", from the header. The account the model line goes
+// on to name is left out.
+func (t *Transcript) Banner() (version string, model string) {
+	for _, b := range t.Filter(Header) {
+		for _, line := range b.Lines {
+			if m := versionRegex.FindStringSubmatch(line); m != nil && version == "" {
+				version = m[1]
+				continue
+			}
+
+			// the model is in the left column of the boxed banner, after the logo
+			// in the compact one
+			cell, _, _ := strings.Cut(strings.TrimLeft(line, " │"), "│")
+			cell = strings.TrimSpace(bannerCell.ReplaceAllString(cell, ""))
+			if model == "" && strings.Contains(cell, " · ") {
+				segments := strings.Split(cell, " · ")
+				model = strings.Join(segments[:min(len(segments), 2)], " · ")
+			}
+		}
+	}
+
+	return version, model
+}
@@ -0,0 +1,130 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sample = `header line
+
+> You are working on day01 of advent of code 2025. Please answer part one of
+ the following questions.
+` + "```" + `
+> not a prompt, this is pasted puzzle text
+● neither is this
+` + "```" + `
+
+● I'll solve this.
+
+● Bash(cd /tmp &&
+      go test)
+  ⎿  ok
+     PASS
+
+● Write(main.go)
+  ⎿  Wrote 3 lines to main.go
+     package main
+
+> now do part two
+`
+
+func TestParseKinds(t *testing.T) {
+	tr := Parse(sample)
+
+	expected := []Kind{Header, Prompt, Message, ToolCall, ToolOutput, ToolCall, ToolOutput, Prompt}
+	if len(tr.Blocks) != len(expected) {
+		t.Fatalf("expected %d blocks, got %d", len(expected), len(tr.Blocks))
+	}
+
+	for i, k := range expected {
+		if tr.Blocks[i].Kind != k {
+			t.Errorf("block %d: expected %v, got %v", i, k, tr.Blocks[i].Kind)
+		}
+	}
+
+	if tr.Blocks[3].Line != 12 {
+		t.Errorf("expected tool call on line 12, got %d", tr.Blocks[3].Line)
+	}
+}
+
+func TestTurnsAndInvocations(t *testing.T) {
+	tr := Parse(sample)
+
+	turns := tr.Turns()
+	if len(turns) != 3 {
+		t.Fatalf("expected 3 turns, got %d", len(turns))
+	}
+
+	if turns[0].Role != User || turns[1].Role != Assistant || turns[2].Role != User {
+		t.Errorf("unexpected roles: %v %v %v", turns[0].Role, turns[1].Role, turns[2].Role)
+	}
+
+	invocations := turns[1].Invocations()
+	if len(invocations) != 2 {
+		t.Fatalf("expected 2 invocations, got %d", len(invocations))
+	}
+
+	if invocations[0].Name != "Bash" || invocations[0].Args != "cd /tmp && go test" {
+		t.Errorf("unexpected invocation: %q(%q)", invocations[0].Name, invocations[0].Args)
+	}
+
+	if invocations[0].Output == nil || invocations[0].Output.Body() != "ok\nPASS" {
+		t.Errorf("unexpected output for %s", invocations[0].Name)
+	}
+
+	if invocations[1].Name != "Write" || invocations[1].Args != "main.go" {
+		t.Errorf("unexpected invocation: %q(%q)", invocations[1].Name, invocations[1].Args)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	if got := Parse(sample).String(); got != sample {
+		t.Errorf("round trip changed the sample transcript:\n%s", got)
+	}
+
+	files, err := filepath.Glob("../../../../day*/ai/day*_conversation.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := Parse(string(content)).String(); got != string(content) {
+			t.Errorf("round trip changed %s", file)
+		}
+	}
+}
+
+func TestBanner(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{
+			name:   "compact",
+			header: "\n ▐▛███▜▌   Claude Code v2.0.56\n▝▜█████▛▘  Sonnet 4.5 · Claude Pro\n  ▘▘ ▝▝    ~/fun/aoc/2025/day01/ai\n",
+		},
+		{
+			name: "boxed",
+			header: "╭─── Claude Code v2.0.56 ───────────────╮\n" +
+				"│          ▐▛███▜▌          │ Tips     │\n" +
+				"│   Sonnet 4.5 · Claude Pro · someone@example.com's   │          │\n" +
+				"│   ~/fun/aoc/2025/day02/ai   │          │\n" +
+				"╰───────────────────────────────────────╯\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, model := Parse(tt.header + "\n> hello\n").Banner()
+			if version != "2.0.56" || model != "Sonnet 4.5 · Claude Pro" {
+				t.Errorf("expected 2.0.56 and Sonnet 4.5 · Claude Pro, got %q and %q", version, model)
+			}
+		})
+	}
+}
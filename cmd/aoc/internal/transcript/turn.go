@@ -0,0 +1,89 @@
+package transcript
+
+type Role int
+
+const (
+	User Role = iota + 1
+	Assistant
+)
+
+func (r Role) String() string {
+	switch r {
+	case User:
+		return "user"
+	case Assistant:
+		return "assistant"
+	}
+
+	return "unknown"
+}
+
+// Turn is one user prompt, or everything the assistant did between two user
+// prompts.
+type Turn struct {
+	Role   Role
+	Blocks []*Block
+}
+
+// Invocation is a single tool call made by the assistant together with the
+// output it printed, if any.
+type Invocation struct {
+	Name   string
+	Args   string
+	Call   *Block
+	Output *Block
+}
+
+// Turns groups the blocks of the transcript into alternating user and
+// assistant turns. The header is not part of any turn.
+func (t *Transcript) Turns() []Turn {
+	var turns []Turn
+	for _, b := range t.Blocks {
+		var role Role
+		switch b.Kind {
+		case Header:
+			continue
+		case Prompt:
+			role = User
+		default:
+			role = Assistant
+		}
+
+		// every prompt is a turn of its own, assistant blocks are grouped
+		if role == User || len(turns) == 0 || turns[len(turns)-1].Role != role {
+			turns = append(turns, Turn{Role: role})
+		}
+		turns[len(turns)-1].Blocks = append(turns[len(turns)-1].Blocks, b)
+	}
+
+	return turns
+}
+
+// Invocations returns the tool calls made during the turn.
+func (t Turn) Invocations() []Invocation {
+	var invocations []Invocation
+	for i, b := range t.Blocks {
+		name, args, ok := b.Tool()
+		if !ok {
+			continue
+		}
+
+		inv := Invocation{Name: name, Args: args, Call: b}
+		if i+1 < len(t.Blocks) && t.Blocks[i+1].Kind == ToolOutput {
+			inv.Output = t.Blocks[i+1]
+		}
+		invocations = append(invocations, inv)
+	}
+
+	return invocations
+}
+
+// Invocations returns every tool call in the transcript.
+func (t *Transcript) Invocations() []Invocation {
+	var invocations []Invocation
+	for _, turn := range t.Turns() {
+		invocations = append(invocations, turn.Invocations()...)
+	}
+
+	return invocations
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Snapshot is the size and modification time of the watched files, by path.
+type Snapshot map[string]FileStamp
+
+// FileStamp tells if a file changed without reading it.
+type FileStamp struct {
+	Size    int64
+	ModTime time.Time
+}
+
+// examplePattern matches example inputs kept next to the input or in a
+// solver directory, e.g. example or example2.txt.
+const examplePattern = "example*"
+
+// TakeSnapshot stamps the input, answers and example files of the day
+// directory and the sources and examples of the solvers, at any depth but
+// for hidden and build directories.
+func TakeSnapshot(dayDir string, solvers []*Solver) (Snapshot, error) {
+	snapshot := make(Snapshot)
+
+	entries, err := os.ReadDir(dayDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if matched, _ := filepath.Match(examplePattern, name); !entry.IsDir() && (matched || name == "input" || name == "answers") {
+			if err := snapshot.add(filepath.Join(dayDir, name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, s := range solvers {
+		err := filepath.WalkDir(s.Dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != s.Dir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "target") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			for _, pattern := range append([]string{examplePattern}, s.Language.Sources...) {
+				if matched, _ := filepath.Match(pattern, d.Name()); matched {
+					return snapshot.add(path)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+func (s Snapshot) add(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		// removed while we looked
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s[path] = FileStamp{Size: info.Size(), ModTime: info.ModTime()}
+	return nil
+}
+
+// Changed returns the paths added, removed or changed since old, sorted.
+func (s Snapshot) Changed(old Snapshot) []string {
+	var changed []string
+	for path, stamp := range s {
+		if previous, ok := old[path]; !ok || previous.Size != stamp.Size || !previous.ModTime.Equal(stamp.ModTime) {
+			changed = append(changed, path)
+		}
+	}
+	for path := range old {
+		if _, ok := s[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+
+	return changed
+}
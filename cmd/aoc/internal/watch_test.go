@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	dayDir := t.TempDir()
+	golang, err := LookupLanguage("go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	solver := &Solver{Name: "human", Dir: filepath.Join(dayDir, "human"), Language: golang}
+
+	files := map[string]string{
+		"input":                   "1\n",
+		"example":                 "2\n",
+		"notes.md":                "not watched\n",
+		"human/main.go":           "package main\n",
+		"human/puzzle/puzzle.go":  "package puzzle\n",
+		"human/solution":          "binary, not watched\n",
+		"human/.git/HEAD":         "not watched\n",
+		"human/puzzle/example.in": "3\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dayDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	before, err := TakeSnapshot(dayDir, []*Solver{solver})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(before) != 5 {
+		t.Errorf("expected 5 watched files, got %v", before)
+	}
+
+	// same size, later modification time
+	puzzle := filepath.Join(dayDir, "human", "puzzle", "puzzle.go")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(puzzle, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dayDir, "example")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dayDir, "human", "extra.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := TakeSnapshot(dayDir, []*Solver{solver})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var changed []string
+	for _, path := range after.Changed(before) {
+		rel, _ := filepath.Rel(dayDir, path)
+		changed = append(changed, filepath.ToSlash(rel))
+	}
+	if got := strings.Join(changed, ","); got != "example,human/extra.go,human/puzzle/puzzle.go" {
+		t.Errorf("unexpected changes %s", got)
+	}
+	if changed := after.Changed(after); len(changed) != 0 {
+		t.Errorf("expected no changes, got %v", changed)
+	}
+}
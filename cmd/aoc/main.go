@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 )
@@ -16,10 +18,34 @@ func main() {
 	switch command {
 	case "create":
 		createDay()
+	case "run":
+		exit(runDay())
+	case "check":
+		checkDays()
+	case "answer":
+		answerDay()
+	case "bench":
+		benchDays()
+	case "difftest":
+		diffTestDay()
+	case "gen":
+		genDay()
+	case "watch":
+		watchDay()
+	case "stats":
+		statsDays()
 	case "redact":
 		redactDay()
+	case "unredact":
+		unredactDay()
 	case "fetch":
 		fetchDay()
+	case "verify":
+		verifyDays()
+	case "prompt":
+		promptDay()
+	case "history-audit":
+		historyAudit()
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", command)
 		printUsage()
@@ -31,12 +57,88 @@ func printUsage() {
 	fmt.Println("Usage: aoc <command> [arguments]")
 	fmt.Println()
 	fmt.Println("Commands:")
-	fmt.Println("  create <day_number>  Create directory structure for a day")
-	fmt.Println("  redact <day_number>  Redact answers and puzzle text from conversation")
-	fmt.Println("  fetch <day_number>   Fetch puzzle content from adventofcode.com")
+	fmt.Println("  create <day_number>    Create directory structure for a day")
+	fmt.Println("  run <day_number>       Build and run a day's solvers, with timings")
+	fmt.Println("  check [days]           Check every solver against the day's answers file")
+	fmt.Println("  answer <day> <part>    Check a guess against known wrong answers, or record one")
+	fmt.Println("  bench <day|all>        Benchmark the AI and human solvers side by side")
+	fmt.Println("  difftest <day_number>  Find the smallest generated input the two solvers disagree on")
+	fmt.Println("  gen <day_number>       Generate a random input of any size")
+	fmt.Println("  watch <day_number>     Rerun a day's tests and solvers whenever they change")
+	fmt.Println("  stats [days]           Count turns, tool calls and test runs in the AI conversations")
+	fmt.Println("  redact <day_number>    Redact answers and puzzle text from conversation")
+	fmt.Println("  unredact <day_number>  Restore a conversation redacted with --restore-map")
+	fmt.Println("  fetch <day_number>     Fetch puzzle content from adventofcode.com")
+	fmt.Println("  prompt <day> <part>    Build the AI solver prompt from the fetched puzzle")
+	fmt.Println("  verify                 Check every day for leaked answers, puzzle text and input")
+	fmt.Println("  history-audit          Find leaks in git history and write a script purging them")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  aoc create 5")
+	fmt.Println("  aoc create --lang rust 8")
+	fmt.Println("  aoc run 4 --solver human")
+	fmt.Println("  aoc run 4 --in-process")
+	fmt.Println("  aoc check 1-6 --json")
+	fmt.Println("  aoc answer 5 1 1100 --too-high")
+	fmt.Println("  aoc bench all --runs 50")
+	fmt.Println("  aoc difftest 1 -n 1000")
+	fmt.Println("  aoc gen 4 --size 2000 --seed 7")
+	fmt.Println("  aoc bench 4 --size 2000")
+	fmt.Println("  aoc watch 7 --solver human")
+	fmt.Println("  aoc stats 1-6 --csv")
 	fmt.Println("  aoc redact 4")
+	fmt.Println("  aoc redact 1-6")
+	fmt.Println("  aoc redact --all")
+	fmt.Println("  aoc unredact 4")
 	fmt.Println("  aoc fetch 7")
+	fmt.Println("  aoc prompt 7 1 --write")
+	fmt.Println("  aoc verify --install-hook")
+	fmt.Println("  aoc history-audit --script rewrite.sh")
+}
+
+// parseFlags parses flags wherever they appear in args and returns the
+// positional arguments, so `aoc redact 4 --restore-map` works as well as
+// `aoc redact --restore-map 4`.
+func parseFlags(flags *flag.FlagSet, args []string) []string {
+	positional, _ := parseArgs(flags, args)
+	return positional
+}
+
+// errUsage is returned by commands once they printed how to use them.
+var errUsage = errors.New("usage")
+
+// parseArgs is parseFlags for flag sets continuing on error. The flag set
+// printed what was wrong already, so the error is errUsage, or flag.ErrHelp
+// for -h.
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errUsage
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// exit ends a command returning err: an error is printed unless it is only
+// errUsage, and -h isn't a failure.
+func exit(err error) {
+	switch {
+	case err == nil:
+		return
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(0)
+	case !errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	os.Exit(1)
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+func promptDay() {
+	flags := flag.NewFlagSet("prompt", flag.ExitOnError)
+	write := flags.Bool("write", false, "write the prompt to dayNN/ai/prompt_partN.md instead of stdout")
+	templatePath := flags.String("template", internal.PromptTemplate, "prompt template, the built-in one is used when it doesn't exist")
+	args := parseFlags(flags, os.Args[2:])
+
+	if len(args) < 2 {
+		fmt.Fprintf(os.Stderr, "Usage: aoc prompt [--write] [--template path] <day_number> <part>\n")
+		fmt.Fprintf(os.Stderr, "Example: aoc prompt 7 1\n")
+		os.Exit(1)
+	}
+
+	dayNum, err := strconv.Atoi(args[0])
+	if err != nil || dayNum < 1 || dayNum > 25 {
+		fmt.Fprintf(os.Stderr, "Error: day number must be between 1 and 25\n")
+		os.Exit(1)
+	}
+
+	part, err := strconv.Atoi(args[1])
+	if err != nil || part < 1 || part > 2 {
+		fmt.Fprintf(os.Stderr, "Error: part must be 1 or 2\n")
+		os.Exit(1)
+	}
+
+	dayStr := fmt.Sprintf("%02d", dayNum)
+	contentPath := filepath.Join(fmt.Sprintf("day%s", dayStr), fmt.Sprintf("day%s_content.txt", dayStr))
+	content, err := os.ReadFile(contentPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading puzzle, run `aoc fetch %d` first: %v\n", dayNum, err)
+		os.Exit(1)
+	}
+
+	puzzle, err := internal.PuzzlePart(string(content), part)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	prompt, err := internal.RenderPrompt(internal.PromptData{
+		Day:    dayNum,
+		Year:   internal.Year,
+		Title:  internal.PuzzleTitle(string(content)),
+		Part:   part,
+		Puzzle: puzzle,
+	}, *templatePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !*write {
+		fmt.Print(prompt)
+		return
+	}
+
+	// the prompt holds the puzzle text, it is gitignored
+	promptPath := filepath.Join(fmt.Sprintf("day%s", dayStr), "ai", fmt.Sprintf("prompt_part%d.md", part))
+	if err := os.WriteFile(promptPath, []byte(prompt), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing prompt: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Successfully wrote day %d part %d prompt to %s\n", dayNum, part, promptPath)
+}
@@ -1,66 +1,340 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"text/tabwriter"
 
 	"github.com/IanShearer/aoc/cmd/aoc/internal"
 )
 
+// redactOptions are the settings shared by every day of a run.
+type redactOptions struct {
+	policy     *internal.Policy
+	salt       []byte
+	restoreMap bool
+}
+
+type redactResult struct {
+	dayNum int
+	counts map[string]int
+	// noAnswers is why answers weren't redacted, empty when they were
+	noAnswers string
+	err       error
+}
+
 func redactDay() {
-	if len(os.Args) < 3 {
-		fmt.Fprintf(os.Stderr, "Usage: aoc redact <day_number>\n")
+	flags := flag.NewFlagSet("redact", flag.ExitOnError)
+	restoreMap := flags.Bool("restore-map", false, "number placeholders and keep the originals in a gitignored .restore.json next to each conversation")
+	all := flags.Bool("all", false, "redact every day that has a conversation")
+	jobs := flags.Int("jobs", runtime.NumCPU(), "number of days redacted at the same time")
+	code := flags.String("code", "", "also look for answers in the Go files of dayNN/ai and dayNN/human, report or fix")
+	verbose := flags.Bool("verbose", false, "with --code, also print why a solution doesn't type check, its numbers are then only reported")
+	policyPath := flags.String("policy", internal.PolicyFile, "redaction policy, the default rules are used when it doesn't exist")
+	args := parseFlags(flags, os.Args[2:])
+
+	if len(args) < 1 && !*all {
+		fmt.Fprintf(os.Stderr, "Usage: aoc redact [--restore-map] [--policy redact.json] [--code report|fix] [--verbose] [--all] <day_number|from-to>\n")
 		fmt.Fprintf(os.Stderr, "Example: aoc redact 1\n")
+		fmt.Fprintf(os.Stderr, "Example: aoc redact 1-6\n")
+		os.Exit(1)
+	}
+
+	if *code != "" && *code != "report" && *code != "fix" {
+		fmt.Fprintf(os.Stderr, "Error: --code must be report or fix\n")
+		os.Exit(1)
+	}
+
+	daysArg := "all"
+	if !*all {
+		daysArg = args[0]
+	}
+
+	dayNums, err := redactDays(daysArg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	policy, err := internal.LoadPolicy(*policyPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: reading policy: %v\n", err)
+		os.Exit(1)
+	}
+
+	salt, err := internal.LoadSalt()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: reading %s: %v\n", internal.SaltFile, err)
+		os.Exit(1)
+	}
+
+	opts := redactOptions{policy: &policy, salt: salt, restoreMap: *restoreMap}
+
+	// a single day keeps the old behaviour, no table and a hard failure
+	if len(dayNums) == 1 {
+		_, noAnswers, err := redactConversations(dayNums[0], opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if noAnswers != "" {
+			fmt.Fprintf(os.Stderr, "Warning: day %d: %s, answers were not redacted\n", dayNums[0], noAnswers)
+		}
+
+		fmt.Printf("Successfully redacted day %d conversation\n", dayNums[0])
+		redactCode(dayNums, *code, policy, *verbose)
+		return
+	}
+
+	results := make([]redactResult, len(dayNums))
+	sem := make(chan struct{}, max(*jobs, 1))
+	var wg sync.WaitGroup
+	for i, dayNum := range dayNums {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			counts, noAnswers, err := redactConversations(dayNum, opts)
+			results[i] = redactResult{dayNum: dayNum, counts: counts, noAnswers: noAnswers, err: err}
+		}()
+	}
+	wg.Wait()
+
+	failed := false
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DAY\tANSWERS\tPUZZLE\tPRIVACY\tOTHER\tSTATUS")
+	for _, r := range results {
+		if r.err != nil {
+			failed = true
+			fmt.Fprintf(w, "%02d\t-\t-\t-\t-\terror: %v\n", r.dayNum, r.err)
+			continue
+		}
+
+		// anything from literal, regex, input or named rules of the policy
+		other := 0
+		for rule, count := range r.counts {
+			if rule != "answer" && rule != "puzzle" && rule != "privacy" {
+				other += count
+			}
+		}
+
+		answers, status := strconv.Itoa(r.counts["answer"]), "ok"
+		if r.noAnswers != "" {
+			answers, status = "-", "ok, answers skipped: "+r.noAnswers
+		}
+		fmt.Fprintf(w, "%02d\t%s\t%d\t%d\t%d\t%s\n", r.dayNum, answers, r.counts["puzzle"], r.counts["privacy"], other, status)
+	}
+	w.Flush()
+
+	if failed {
+		os.Exit(1)
+	}
+
+	redactCode(dayNums, *code, policy, *verbose)
+}
+
+// redactCode runs the optional pass over the solutions' Go files. Answers
+// that are reported, or couldn't be fixed, fail the command. With verbose,
+// type check errors are printed as warnings.
+func redactCode(dayNums []int, mode string, policy internal.Policy, verbose bool) {
+	if mode == "" {
+		return
+	}
+
+	var warnf func(format string, args ...any)
+	if verbose {
+		warnf = func(format string, args ...any) {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", fmt.Sprintf(format, args...))
+		}
+	}
+
+	left, err := scrubCode(dayNums, mode == "fix", policy, warnf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	dayNum, err := strconv.Atoi(os.Args[2])
-	if err != nil || dayNum < 1 || dayNum > 25 {
-		fmt.Fprintf(os.Stderr, "Error: day number must be between 1 and 25\n")
+	if left > 0 {
+		fmt.Fprintf(os.Stderr, "Found %d answer(s) in solution code\n", left)
 		os.Exit(1)
 	}
+}
 
+// redactDays returns the days of a day argument to redact. A single day is
+// taken as is, a range or all of them only keeps the days that have a
+// conversation.
+func redactDays(arg string) ([]int, error) {
+	dayNums, err := parseDays(arg)
+	if err != nil || (len(dayNums) == 1 && arg != "all") {
+		return dayNums, err
+	}
+
+	var days []int
+	for _, dayNum := range dayNums {
+		files, err := internal.ConversationFiles(dayNum)
+		if err == nil && len(files) > 0 {
+			days = append(days, dayNum)
+		}
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no conversation found for days %s", arg)
+	}
+
+	return days, nil
+}
+
+// redactConversations redacts every conversation of a day and returns the
+// number of redactions per rule. The answers are the accepted ones and the
+// rejected guesses; without any every other rule still runs, and why answers
+// weren't redacted is returned. What was removed is recorded in the day's
+// redaction.json manifest.
+func redactConversations(dayNum int, opts redactOptions) (map[string]int, string, error) {
 	// Format day number with leading zero if needed
 	dayStr := fmt.Sprintf("%02d", dayNum)
 
 	// Read answers file
+	var noAnswers string
 	answersPath := filepath.Join(fmt.Sprintf("day%s", dayStr), "answers")
 	answers, err := internal.ReadAnswers(answersPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading answers file: %v\n", err)
-		os.Exit(1)
+	switch {
+	case os.IsNotExist(err):
+		noAnswers = "answers file is missing"
+	case err != nil:
+		return nil, "", fmt.Errorf("reading answers file: %w", err)
+	case len(answers) == 0:
+		noAnswers = "answers file has no answers"
 	}
 
-	// Read conversation file
-	conversationPath := filepath.Join(fmt.Sprintf("day%s", dayStr), "ai", fmt.Sprintf("day%s_conversation.txt", dayStr))
-	content, err := os.ReadFile(conversationPath)
+	redactor := internal.Redactor{
+		DayNum:  dayNum,
+		Answers: answers,
+		Policy:  opts.policy,
+		Warnf: func(format string, args ...any) {
+			fmt.Fprintf(os.Stderr, "Warning: day %s: %s\n", dayStr, fmt.Sprintf(format, args...))
+		},
+	}
+
+	// When we have fetched the puzzle we know exactly what to look for,
+	// otherwise the redactor falls back to guessing from code blocks
+	contentPath := filepath.Join(fmt.Sprintf("day%s", dayStr), fmt.Sprintf("day%s_content.txt", dayStr))
+	puzzle, err := os.ReadFile(contentPath)
+	if err == nil && len(strings.TrimSpace(string(puzzle))) > 0 {
+		p := internal.NewPuzzleShingles(string(puzzle))
+		redactor.Puzzle = &p
+	}
+
+	// The input is only looked for by input-lines rules
+	if input, err := os.ReadFile(filepath.Join(fmt.Sprintf("day%s", dayStr), "input")); err == nil {
+		for _, line := range strings.Split(string(input), "\n") {
+			redactor.Input = append(redactor.Input, strings.TrimSpace(line))
+		}
+	}
+
+	// Find the conversation files, the pasted transcript and any session logs
+	conversationPaths, err := internal.ConversationFiles(dayNum)
+	if err != nil || len(conversationPaths) == 0 {
+		return nil, "", fmt.Errorf("no conversation file found for day %d", dayNum)
+	}
+
+	manifest, err := internal.NewManifest(dayNum, opts.policy.ForDay(dayNum), opts.salt)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading conversation file: %v\n", err)
-		os.Exit(1)
+		return nil, "", err
 	}
 
-	// Perform redactions
-	redacted := string(content)
+	counts := make(map[string]int)
+	for _, conversationPath := range conversationPaths {
+		content, err := os.ReadFile(conversationPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading conversation file: %w", err)
+		}
+
+		// A restore map from an earlier run is relative to the original, so
+		// start over from the original and write a map covering both runs
+		original, err := restoreConversation(conversationPath, string(content))
+		if err != nil {
+			return nil, "", fmt.Errorf("restoring %s: %w", conversationPath, err)
+		}
+		keepMap := opts.restoreMap || original != string(content)
+
+		// Perform redactions
+		r := redactor
+		r.Numbered = keepMap
+		redacted, restore, err := r.Redact(original)
+		if err != nil {
+			return nil, "", fmt.Errorf("redacting %s: %w", conversationPath, err)
+		}
+
+		for _, redaction := range restore.Redactions {
+			counts[redaction.Rule]++
+		}
+		manifest.Add(conversationPath, redacted, restore.Redactions, opts.salt)
+
+		// Write back to file
+		err = os.WriteFile(conversationPath, []byte(redacted), 0644)
+		if err != nil {
+			return nil, "", fmt.Errorf("writing conversation file: %w", err)
+		}
 
-	// 1. Redact answers
-	for _, answer := range answers {
-		if answer != "" {
-			redacted = strings.ReplaceAll(redacted, answer, "(REDACTED)")
+		if keepMap {
+			restore.Path = conversationPath
+			if err := writeRestoreMap(restore); err != nil {
+				return nil, "", fmt.Errorf("writing restore map: %w", err)
+			}
 		}
 	}
 
-	// 2. Redact puzzle code blocks
-	redacted = internal.RedactPuzzleBlocks(redacted, dayNum)
+	if err := manifest.Write(); err != nil {
+		return nil, "", fmt.Errorf("writing manifest: %w", err)
+	}
+
+	return counts, noAnswers, nil
+}
 
-	// Write back to file
-	err = os.WriteFile(conversationPath, []byte(redacted), 0644)
+func restoreMapPath(conversationPath string) string {
+	return conversationPath + ".restore.json"
+}
+
+func writeRestoreMap(restore internal.RestoreMap) error {
+	b, err := json.MarshalIndent(restore, "", "  ")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing conversation file: %v\n", err)
-		os.Exit(1)
+		return err
+	}
+
+	// the map holds the original text, keep it to ourselves
+	return os.WriteFile(restoreMapPath(restore.Path), append(b, '\n'), 0600)
+}
+
+func readRestoreMap(conversationPath string) (internal.RestoreMap, error) {
+	var restore internal.RestoreMap
+
+	b, err := os.ReadFile(restoreMapPath(conversationPath))
+	if err != nil {
+		return restore, err
+	}
+
+	err = json.Unmarshal(b, &restore)
+	return restore, err
+}
+
+// restoreConversation undoes the redaction of a conversation using its
+// restore map. Without a map the content is returned as is.
+func restoreConversation(conversationPath string, content string) (string, error) {
+	restore, err := readRestoreMap(conversationPath)
+	if os.IsNotExist(err) {
+		return content, nil
+	}
+	if err != nil {
+		return "", err
 	}
 
-	fmt.Printf("Successfully redacted day %d conversation\n", dayNum)
+	return internal.ApplyEdits(content, restore.Edits)
 }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+const pastedPuzzle = "> Please answer part one of the following questions.\n```\n" +
+	"--- Day 1: Secret Entrance ---\n" +
+	"The Elves have good news and bad news. For example, suppose the attached\n" +
+	"document contained the following rotations. What is the password?\n" +
+	"```\n\n● Bash(go run main.go)\n  ⎿  Part One: 1052\n"
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRedactConversationsAnswers(t *testing.T) {
+	tests := []struct {
+		name      string
+		answers   string
+		noAnswers string
+		redacted  bool
+	}{
+		{name: "missing", noAnswers: "answers file is missing"},
+		{name: "empty", answers: "\n", noAnswers: "answers file has no answers"},
+		{
+			name:     "only rejected guesses",
+			answers:  `{"parts": [{"part": 1, "rejected": [{"answer": "1052", "feedback": "too high"}]}]}`,
+			redacted: true,
+		},
+		{name: "accepted", answers: "1052\n", redacted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			conversation := filepath.Join("day01", "ai", "day01_conversation.txt")
+			writeFile(t, conversation, pastedPuzzle)
+			if tt.answers != "" {
+				writeFile(t, filepath.Join("day01", "answers"), tt.answers)
+			}
+
+			policy := internal.DefaultPolicy()
+			counts, noAnswers, err := redactConversations(1, redactOptions{policy: &policy, salt: []byte("salt")})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if noAnswers != tt.noAnswers {
+				t.Errorf("expected %q, got %q", tt.noAnswers, noAnswers)
+			}
+
+			b, err := os.ReadFile(conversation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if counts["puzzle"] != 1 || !strings.Contains(string(b), "the text in this box is the puzzle") {
+				t.Errorf("expected the puzzle to be redacted, got %v:\n%s", counts, b)
+			}
+			if redacted := !strings.Contains(string(b), "1052"); redacted != tt.redacted {
+				t.Errorf("expected the answer redacted to be %v, got:\n%s", tt.redacted, b)
+			}
+		})
+	}
+}
+
+func TestRedactDays(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, day := range []string{"01", "03", "04"} {
+		writeFile(t, filepath.Join("day"+day, "ai", "day"+day+"_conversation.txt"), pastedPuzzle)
+	}
+	writeFile(t, filepath.Join("day02", "human", "main.go"), "package main\n")
+
+	tests := []struct {
+		arg      string
+		expected []int
+		err      bool
+	}{
+		{arg: "1-3", expected: []int{1, 3}},
+		{arg: "all", expected: []int{1, 3, 4}},
+		// asked for by itself, a day without a conversation is an error later on
+		{arg: "2", expected: []int{2}},
+		{arg: "5-9", err: true},
+		{arg: "0", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := redactDays(tt.arg)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: unexpected error: %v", tt.arg, err)
+		}
+		if !slices.Equal(got, tt.expected) {
+			t.Errorf("%s: expected days %v, got %v", tt.arg, tt.expected, got)
+		}
+	}
+}
+
+func TestRedactConversationsFetchedPuzzle(t *testing.T) {
+	t.Chdir(t.TempDir())
+	partOne := "--- Day 1: Secret Entrance ---\nThe Elves have good news and bad news. The good news is that they've\ndiscovered project management! The bad news is that the safe has a dial.\n"
+	partTwo := "--- Part Two ---\nYou're sure that's the right password, but the door won't open. You knock,\nbut nobody answers. Count the number of times any click causes the dial to\npoint at 0, regardless of whether it happens during a rotation.\n"
+	writeFile(t, filepath.Join("day01", "day01_content.txt"), partOne+"\n"+partTwo)
+	writeFile(t, filepath.Join("day01", "answers"), "1052\n")
+
+	// part two pasted first and without any hint in the prompt, the fetched
+	// puzzle tells the parts apart
+	conversation := filepath.Join("day01", "ai", "day01_conversation.txt")
+	writeFile(t, conversation, "> Solve this\n```\n"+partTwo+"```\n\n● Okay.\n\n> And this\n```\n"+partOne+"```\n")
+
+	policy := internal.DefaultPolicy()
+	if _, _, err := redactConversations(1, redactOptions{policy: &policy, salt: []byte("salt")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := os.ReadFile(conversation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "> Solve this\n```\n" +
+		"(REDACTED) the text in this box is the puzzle, part two of advent of code 2025 day 01\n" +
+		"```\n\n● Okay.\n\n> And this\n```\n" +
+		"(REDACTED) the text in this box is the puzzle, part one of advent of code 2025 day 01\n" +
+		"```\n"
+	if string(b) != expected {
+		t.Errorf("unexpected redaction.\n\nExpecting:\n%s\n\nGot:\n%s", expected, b)
+	}
+}
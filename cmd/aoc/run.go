@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+// runDay returns its errors rather than exiting, so the solve binary's
+// temporary directory is always removed.
+func runDay() error {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	solverName := flags.String("solver", "", "solver to run, ai or human, both when not set")
+	inputPath := flags.String("input", "", "run on this file instead of the day's input")
+	inProcess := flags.Bool("in-process", false, "call Go solvers' puzzle packages through the solvers registry instead of their own binaries")
+	args, err := parseArgs(flags, os.Args[2:])
+	if err != nil {
+		return err
+	}
+
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: aoc run [--solver ai|human] [--input path] [--in-process] <day_number>\n")
+		fmt.Fprintf(os.Stderr, "Example: aoc run 4 --solver human\n")
+		return errUsage
+	}
+
+	dayNum, err := strconv.Atoi(args[0])
+	if err != nil || dayNum < 1 || dayNum > 25 {
+		return errors.New("day number must be between 1 and 25")
+	}
+
+	names := internal.SolverNames
+	if *solverName != "" {
+		names = []string{*solverName}
+	}
+
+	// One binary has every registered solver, built once for all of them
+	solveBinary := ""
+	if *inProcess {
+		tmp, err := os.MkdirTemp("", "aoc-solve-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+
+		if solveBinary, err = internal.BuildSolve(tmp); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for _, name := range names {
+		solver, err := internal.NewSolver(dayNum, name)
+		if err != nil {
+			// a day only has to have the solvers asked for by name
+			if *solverName == "" && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+
+		var result *internal.RunResult
+		if solveBinary != "" && solver.InProcess() {
+			result, err = solver.RunInProcess(solveBinary, *inputPath)
+		} else if err = solver.Build(); err == nil {
+			result, err = solver.Run(*inputPath)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			failed++
+			if result == nil {
+				continue
+			}
+		}
+
+		printRun(solver, result, solveBinary != "" && solver.InProcess())
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of the solvers failed", failed)
+	}
+	return nil
+}
+
+func printRun(solver *internal.Solver, result *internal.RunResult, inProcess bool) {
+	how := solver.Language.Name
+	if inProcess {
+		how += ", in-process"
+	}
+	fmt.Printf("%s (%s)\n", solver, how)
+
+	for i, part := range []string{"One", "Two"} {
+		if result.Answers[i] == "" {
+			fmt.Printf("  Part %s: (not printed)\n", part)
+			continue
+		}
+		fmt.Printf("  Part %s: %-20s %v\n", part, result.Answers[i], formatDuration(result.Times[i]))
+	}
+
+	rss := "unknown"
+	if result.MaxRSS > 0 {
+		rss = internal.FormatBytes(result.MaxRSS)
+	}
+	fmt.Printf("  wall %v, peak RSS %s\n", formatDuration(result.Wall), rss)
+
+	// Show what the solver printed when it didn't print answers we understand
+	if result.Answers[0] == "" && result.Answers[1] == "" && strings.TrimSpace(result.Output) != "" {
+		fmt.Printf("  output:\n%s", result.Output)
+	}
+}
+
+// formatDuration rounds a duration to three significant digits.
+func formatDuration(d time.Duration) time.Duration {
+	unit := time.Duration(1)
+	for d >= 1000*unit {
+		unit *= 10
+	}
+	return d.Round(unit)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"testing"
+)
+
+func TestRunDayArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want error
+	}{
+		{"no day", nil, errUsage},
+		{"unknown flag", []string{"--fast", "4"}, errUsage},
+		{"help", []string{"-h"}, flag.ErrHelp},
+	}
+
+	args := os.Args
+	t.Cleanup(func() { os.Args = args })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Args = append([]string{"aoc", "run"}, tt.args...)
+			if err := runDay(); !errors.Is(err, tt.want) {
+				t.Errorf("runDay() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	os.Args = []string{"aoc", "run", "26"}
+	if err := runDay(); err == nil || errors.Is(err, errUsage) {
+		t.Errorf("expected an error naming the bad day, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+// scrubCode looks for answers in the Go files of the ai and human solutions
+// of each day, and of their puzzle packages. With fix, literals are swapped
+// for a call loading the answer from the answers file and comments are
+// redacted. Answers the policy allows are left alone, as they are in the
+// conversations. Why a package doesn't type check goes to warnf when it is
+// set. It returns the number of answers left in the code.
+func scrubCode(dayNums []int, fix bool, policy internal.Policy, warnf func(format string, args ...any)) (int, error) {
+	left := 0
+	for _, dayNum := range dayNums {
+		answers, err := internal.ReadAcceptedAnswers(filepath.Join(fmt.Sprintf("day%02d", dayNum), "answers"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return left, fmt.Errorf("reading answers file: %w", err)
+		}
+		answers = policy.ForDay(dayNum).WithoutAllowed(answers)
+
+		for _, solver := range []string{"ai", "human"} {
+			// the solution's code is in its puzzle package, main only calls it
+			solverDir := filepath.Join(fmt.Sprintf("day%02d", dayNum), solver)
+			for _, dir := range []string{solverDir, filepath.Join(solverDir, "puzzle")} {
+				if _, err := os.Stat(dir); os.IsNotExist(err) {
+					continue
+				}
+
+				found, err := internal.FindCodeAnswers(dir, answers, warnf)
+				if err != nil {
+					return left, err
+				}
+
+				for _, a := range found {
+					fmt.Println(a)
+					if !fix || a.Fix == "" {
+						left++
+					}
+				}
+
+				if fix && len(found) > 0 {
+					if err := fixCodeAnswers(dir, found); err != nil {
+						return left, err
+					}
+				}
+			}
+		}
+	}
+
+	return left, nil
+}
+
+// fixCodeAnswers rewrites the files of a solution and makes sure it still
+// builds, putting the files back as they were when it doesn't.
+func fixCodeAnswers(dir string, found []internal.CodeAnswer) error {
+	originals := make(map[string][]byte)
+	fixed := 0
+	for _, a := range found {
+		if _, ok := originals[a.Path]; ok {
+			continue
+		}
+
+		original, err := os.ReadFile(a.Path)
+		if err != nil {
+			return err
+		}
+		originals[a.Path] = original
+
+		content, n, err := internal.FixCodeAnswers(a.Path, found)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			continue
+		}
+
+		if err := os.WriteFile(a.Path, content, 0644); err != nil {
+			return err
+		}
+		fixed += n
+	}
+
+	if fixed == 0 {
+		return nil
+	}
+
+	// go vet builds the tests too
+	cmd := exec.Command("go", "vet", "./"+filepath.ToSlash(dir))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		for path, original := range originals {
+			os.WriteFile(path, original, 0644)
+		}
+		return fmt.Errorf("%s no longer builds with the answers replaced, left it as it was:\n%s", dir, out)
+	}
+
+	fmt.Printf("Fixed %d answer(s) in %s\n", fixed, dir)
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+func TestScrubCodeAllow(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeFile(t, filepath.Join("day01", "answers"), "1052\n2025\n")
+	writeFile(t, filepath.Join("day01", "ai", "main.go"), "package main\n\n// part one is 1052, solved in 2025\nfunc main() {}\n")
+
+	tests := []struct {
+		name     string
+		policy   internal.Policy
+		expected int
+	}{
+		{name: "default", policy: internal.DefaultPolicy(), expected: 2},
+		{name: "allowed", policy: internal.Policy{Allow: []string{"2025"}}, expected: 1},
+		{name: "allowed for the day", policy: internal.Policy{Days: map[string]internal.DayPolicy{"1": {Allow: []string{"2025"}}}}, expected: 1},
+		{name: "allowed for another day", policy: internal.Policy{Days: map[string]internal.DayPolicy{"2": {Allow: []string{"2025"}}}}, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, err := scrubCode([]int{1}, false, tt.policy, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if left != tt.expected {
+				t.Errorf("expected %d answers left, got %d", tt.expected, left)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+type statsReport struct {
+	Days  []*internal.Stats `json:"days"`
+	Total *internal.Stats   `json:"total"`
+}
+
+func statsDays() {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	csvOutput := flags.Bool("csv", false, "print the table as CSV")
+	jsonOutput := flags.Bool("json", false, "print a JSON report instead of the table")
+	args := parseFlags(flags, os.Args[2:])
+
+	if *csvOutput && *jsonOutput {
+		fmt.Fprintf(os.Stderr, "Error: --csv and --json don't go together\n")
+		os.Exit(1)
+	}
+
+	daysArg := "all"
+	if len(args) > 0 {
+		daysArg = args[0]
+	}
+
+	dayNums, err := parseDays(daysArg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	report := statsReport{Total: &internal.Stats{Tools: map[string]int{}}}
+	for _, dayNum := range dayNums {
+		stats, err := internal.ReadStats(dayNum)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		report.Days = append(report.Days, stats)
+		report.Total.Add(stats)
+	}
+	if len(report.Days) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no conversations found for days %s\n", daysArg)
+		os.Exit(1)
+	}
+
+	switch {
+	case *jsonOutput:
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(b))
+	case *csvOutput:
+		w := csv.NewWriter(os.Stdout)
+		w.WriteAll(statsRows(report))
+		if err := w.Error(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, row := range statsRows(report) {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		w.Flush()
+	}
+}
+
+// statsRows lays the report out as a header, a row per day and the totals.
+func statsRows(report statsReport) [][]string {
+	header := []string{"DAY", "VERSION", "MODEL", "P1 TURNS", "P2 TURNS"}
+	for _, kind := range internal.ToolKinds {
+		header = append(header, strings.ToUpper(kind))
+	}
+	header = append(header, "OTHER", "REWRITES", "TEST RUNS", "FAILED")
+
+	row := func(day, version, model string, s *internal.Stats) []string {
+		r := []string{day, version, model, strconv.Itoa(s.Turns[0]), strconv.Itoa(s.Turns[1])}
+		for _, kind := range append(internal.ToolKinds, "Other") {
+			r = append(r, strconv.Itoa(s.Tools[kind]))
+		}
+		return append(r, strconv.Itoa(s.Rewrites), strconv.Itoa(s.TestRuns), strconv.Itoa(s.FailedTests))
+	}
+
+	rows := [][]string{header}
+	for _, s := range report.Days {
+		rows = append(rows, row(fmt.Sprintf("%02d", s.Day), orDash(s.Version), orDash(s.Model), s))
+	}
+
+	return append(rows, row("TOTAL", "", "", report.Total))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
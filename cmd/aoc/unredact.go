@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+func unredactDay() {
+	if len(os.Args) < 3 {
+		fmt.Fprintf(os.Stderr, "Usage: aoc unredact <day_number>\n")
+		fmt.Fprintf(os.Stderr, "Example: aoc unredact 1\n")
+		os.Exit(1)
+	}
+
+	dayNum, err := strconv.Atoi(os.Args[2])
+	if err != nil || dayNum < 1 || dayNum > 25 {
+		fmt.Fprintf(os.Stderr, "Error: day number must be between 1 and 25\n")
+		os.Exit(1)
+	}
+
+	conversationPaths, err := internal.ConversationFiles(dayNum)
+	if err != nil || len(conversationPaths) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no conversation file found for day %d\n", dayNum)
+		os.Exit(1)
+	}
+
+	restored := 0
+	for _, conversationPath := range conversationPaths {
+		if _, err := os.Stat(restoreMapPath(conversationPath)); os.IsNotExist(err) {
+			continue
+		}
+
+		content, err := os.ReadFile(conversationPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading conversation file: %v\n", err)
+			os.Exit(1)
+		}
+
+		original, err := restoreConversation(conversationPath, string(content))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error restoring %s: %v\n", conversationPath, err)
+			os.Exit(1)
+		}
+
+		if err := os.WriteFile(conversationPath, []byte(original), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing conversation file: %v\n", err)
+			os.Exit(1)
+		}
+
+		// the map described the redacted file, it means nothing now
+		if err := os.Remove(restoreMapPath(conversationPath)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error removing restore map: %v\n", err)
+			os.Exit(1)
+		}
+		restored++
+	}
+
+	if restored == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no restore map found for day %d, redact with --restore-map first\n", dayNum)
+		os.Exit(1)
+	}
+
+	// the manifest described the redacted files too
+	if err := os.Remove(internal.ManifestPath(dayNum)); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error removing manifest: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Successfully restored day %d conversation\n", dayNum)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+func verifyDays() {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	installHook := flags.Bool("install-hook", false, "install a git pre-commit hook running verify on staged files")
+	staged := flags.Bool("staged", false, "only scan files staged for commit, as they are in the index")
+	flags.Parse(os.Args[2:])
+
+	if *installHook {
+		hookPath, err := internal.InstallPreCommitHook()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error installing pre-commit hook: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Successfully installed pre-commit hook to %s\n", hookPath)
+		return
+	}
+
+	// Load what must not leak for every day
+	dayNums, err := internal.DayDirs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing days: %v\n", err)
+		os.Exit(1)
+	}
+
+	days := make(map[int]internal.DayData)
+	for _, dayNum := range dayNums {
+		days[dayNum] = internal.LoadDayData(dayNum)
+	}
+
+	// Collect the files to scan along with their content
+	var files []string
+	if *staged {
+		files, err = internal.StagedFiles()
+	} else {
+		files, err = repositoryFiles()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing files: %v\n", err)
+		os.Exit(1)
+	}
+
+	var leaks []internal.Leak
+	for _, file := range files {
+		if !internal.IsScannable(file) {
+			continue
+		}
+
+		var content string
+		if *staged {
+			content, err = internal.StagedContent(file)
+		} else {
+			var b []byte
+			b, err = os.ReadFile(file)
+			content = string(b)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", file, err)
+			os.Exit(1)
+		}
+
+		// files of a day are checked against that day, anything else against
+		// every day
+		var check []internal.DayData
+		if dayNum := internal.DayOfPath(file); dayNum != 0 {
+			if day, ok := days[dayNum]; ok {
+				check = append(check, day)
+			}
+		} else {
+			for _, dayNum := range dayNums {
+				check = append(check, days[dayNum])
+			}
+		}
+
+		leaks = append(leaks, internal.FindLeaks(file, content, check)...)
+	}
+
+	// conversations must still be what the last redaction run wrote, with
+	// the policy we have now
+	problems, err := checkManifests(dayNums, *staged)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking manifests: %v\n", err)
+		os.Exit(1)
+	}
+
+	sort.SliceStable(leaks, func(i, j int) bool {
+		if leaks[i].Path != leaks[j].Path {
+			return leaks[i].Path < leaks[j].Path
+		}
+		return leaks[i].Line < leaks[j].Line
+	})
+
+	for _, leak := range leaks {
+		fmt.Println(leak)
+	}
+
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+
+	if len(leaks) > 0 {
+		fmt.Fprintf(os.Stderr, "Found %d leak(s), run `aoc redact <day_number>` or fix them by hand\n", len(leaks))
+		os.Exit(1)
+	}
+
+	if len(problems) > 0 {
+		fmt.Fprintf(os.Stderr, "Found %d outdated redaction manifest(s), run `aoc redact <day_number>`\n", len(problems))
+		os.Exit(1)
+	}
+
+	fmt.Println("No leaks found")
+}
+
+// checkManifests compares the redaction.json of every day that has one with
+// its conversations and the current policy.
+func checkManifests(dayNums []int, staged bool) ([]string, error) {
+	policy, err := internal.LoadPolicy(internal.PolicyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	readFile := os.ReadFile
+	if staged {
+		readFile = func(path string) ([]byte, error) {
+			content, err := internal.StagedContent(path)
+			return []byte(content), err
+		}
+	}
+
+	var problems []string
+	for _, dayNum := range dayNums {
+		manifest, err := internal.ReadManifest(dayNum)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", internal.ManifestPath(dayNum), err)
+		}
+
+		for _, problem := range manifest.Check(policy.ForDay(dayNum), readFile) {
+			problems = append(problems, fmt.Sprintf("%s: %s", filepath.ToSlash(internal.ManifestPath(dayNum)), problem))
+		}
+	}
+
+	return problems, nil
+}
+
+// repositoryFiles returns the files that could be committed. Gitignored ones,
+// like the prompts and restore maps, are skipped when we are in a git
+// repository.
+func repositoryFiles() ([]string, error) {
+	if out, err := internal.Git("ls-files", "--cached", "--others", "--exclude-standard", "-z"); err == nil {
+		var files []string
+		for _, f := range strings.Split(out, "\x00") {
+			// tracked files deleted from the work tree are still listed
+			if _, err := os.Stat(f); f != "" && err == nil {
+				files = append(files, f)
+			}
+		}
+		return files, nil
+	}
+
+	var files []string
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != "." && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		files = append(files, filepath.ToSlash(path))
+		return nil
+	})
+
+	return files, err
+}
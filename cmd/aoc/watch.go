@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IanShearer/aoc/cmd/aoc/internal"
+)
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// watchOutputLines is how much of failing tests' output is shown.
+const watchOutputLines = 15
+
+func watchDay() {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	solverName := flags.String("solver", "", "solver to watch, ai or human, both when not set")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often files are checked for changes")
+	debounce := flags.Duration("debounce", 300*time.Millisecond, "how long files must stay unchanged before rerunning, so a burst of saves reruns once")
+	args := parseFlags(flags, os.Args[2:])
+
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: aoc watch [--solver ai|human] [--interval 500ms] [--debounce 300ms] <day_number>\n")
+		fmt.Fprintf(os.Stderr, "Example: aoc watch 7 --solver human\n")
+		os.Exit(1)
+	}
+
+	dayNum, err := strconv.Atoi(args[0])
+	if err != nil || dayNum < 1 || dayNum > 25 {
+		fmt.Fprintf(os.Stderr, "Error: day number must be between 1 and 25\n")
+		os.Exit(1)
+	}
+
+	names := internal.SolverNames
+	if *solverName != "" {
+		names = []string{*solverName}
+	}
+
+	var solvers []*internal.Solver
+	for _, name := range names {
+		solver, err := internal.NewSolver(dayNum, name)
+		if err != nil {
+			if *solverName == "" && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		solvers = append(solvers, solver)
+	}
+	if len(solvers) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: day %d has no solvers, create it with aoc create %d\n", dayNum, dayNum)
+		os.Exit(1)
+	}
+
+	dayDir := fmt.Sprintf("day%02d", dayNum)
+	snapshot := func() internal.Snapshot {
+		s, err := internal.TakeSnapshot(dayDir, solvers)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return s
+	}
+
+	last := snapshot()
+	rerun(dayNum, solvers, nil)
+	for {
+		time.Sleep(*interval)
+		current := snapshot()
+		changed := current.Changed(last)
+		if len(changed) == 0 {
+			continue
+		}
+
+		// wait for the burst of saves to end
+		for {
+			time.Sleep(*debounce)
+			next := snapshot()
+			more := next.Changed(current)
+			if len(more) == 0 {
+				break
+			}
+			changed = append(changed, more...)
+			current = next
+		}
+		slices.Sort(changed)
+
+		last = current
+		rerun(dayNum, solvers, slices.Compact(changed))
+	}
+}
+
+// watchRun is how a solver did on a rerun.
+type watchRun struct {
+	solver   *internal.Solver
+	tests    string
+	testTime time.Duration
+	output   string
+	result   *internal.RunResult
+	err      error
+}
+
+// rerun tests and runs the solvers and shows a summary of how they did.
+func rerun(dayNum int, solvers []*internal.Solver, changed []string) {
+	answers, err := internal.ReadAcceptedAnswers(answersPath(dayNum))
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: reading answers: %v\n", err)
+	}
+
+	var runs []watchRun
+	for _, solver := range solvers {
+		run := watchRun{solver: solver, tests: internal.StatusPass}
+
+		start := time.Now()
+		output, err := solver.Test()
+		run.testTime = time.Since(start)
+		if err != nil {
+			run.tests = internal.StatusFail
+			run.output = output
+		}
+
+		if run.err = solver.Build(); run.err == nil {
+			run.result, run.err = solver.Run("")
+		}
+		runs = append(runs, run)
+	}
+
+	fmt.Print(clearScreen)
+	fmt.Printf("Day %d at %s", dayNum, time.Now().Format("15:04:05"))
+	if len(changed) > 0 {
+		paths := make([]string, len(changed))
+		for i, path := range changed {
+			paths[i] = filepath.ToSlash(path)
+		}
+		fmt.Printf(", changed %s", strings.Join(paths, ", "))
+	}
+	fmt.Printf("\n\n")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SOLVER\tTESTS\tPART ONE\tPART TWO\tWALL")
+	for _, run := range runs {
+		row := []string{run.solver.Name, fmt.Sprintf("%s %v", run.tests, formatDuration(run.testTime))}
+		if run.result == nil {
+			row = append(row, "-", "-", "-")
+		} else {
+			for i, answer := range run.result.Answers {
+				if answer == "" {
+					answer = "-"
+				}
+				row = append(row, fmt.Sprintf("%s (%s)", answer, internal.CheckAnswer(answers, i+1, run.result.Answers[i])))
+			}
+			row = append(row, formatDuration(run.result.Wall).String())
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	for _, run := range runs {
+		if run.output != "" {
+			fmt.Printf("\n%s tests:\n%s\n", run.solver, lastLines(run.output, watchOutputLines))
+		}
+		if run.err != nil {
+			fmt.Printf("\nError: %v\n", run.err)
+		}
+	}
+
+	fmt.Printf("\nWatching %s for changes, Ctrl-C to stop\n", fmt.Sprintf("day%02d", dayNum))
+}
+
+// lastLines returns the last n lines of s, where test failures end up.
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = append([]string{"..."}, lines[len(lines)-n:]...)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,99 @@
+// Command solve runs any registered solution in-process, all of them by
+// default, from the root of the repository.
+//
+// Usage: solve [-input path] [day [variant]]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/IanShearer/aoc/aocinput"
+	"github.com/IanShearer/aoc/aocsolver"
+	_ "github.com/IanShearer/aoc/solvers"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: solve [-input path] [day [variant]]\n")
+		flag.PrintDefaults()
+	}
+	aocinput.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	args := flag.Args()
+
+	days := aocsolver.Days()
+	if len(args) > 0 {
+		day, err := strconv.Atoi(args[0])
+		if err != nil || day < 1 || day > 25 {
+			fmt.Fprintf(os.Stderr, "Error: day number must be between 1 and 25\n")
+			os.Exit(1)
+		}
+		days = []int{day}
+	}
+
+	type target struct {
+		day     int
+		variant string
+	}
+	var targets []target
+	for _, day := range days {
+		variants := aocsolver.Variants(day)
+		if len(args) > 1 {
+			variants = []string{args[1]}
+		}
+		for _, variant := range variants {
+			targets = append(targets, target{day, variant})
+		}
+	}
+
+	if len(targets) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no solver registered\n")
+		os.Exit(1)
+	}
+
+	// a single solver prints just its answers, like its own main does
+	failed := false
+	for _, t := range targets {
+		if err := solve(t.day, t.variant, len(targets) > 1); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: day%02d/%s: %v\n", t.day, t.variant, err)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func solve(day int, variant string, header bool) error {
+	solver, ok := aocsolver.Lookup(day, variant)
+	if !ok {
+		return fmt.Errorf("no solver registered")
+	}
+
+	input, err := aocinput.LoadDay(fmt.Sprintf("day%02d", day))
+	if err != nil {
+		return err
+	}
+
+	indent := ""
+	if header {
+		fmt.Printf("day%02d/%s\n", day, variant)
+		indent = "  "
+	}
+
+	start := time.Now()
+	err = aocsolver.SolveEach(solver, input, func(part int, a aocsolver.Answer) {
+		fmt.Printf("%sPart %s: %s\n", indent, aocsolver.PartNames[part-1], a)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sTime: %v\n", indent, time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package puzzle
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/IanShearer/aoc/aocinput"
+	"github.com/IanShearer/aoc/aocsolver"
+)
+
+// Range represents a product ID range
+type Range struct {
+	Start int64
+	End   int64
+}
+
+// isInvalidPartOne checks if a product ID is invalid (made of a sequence repeated exactly twice)
+func isInvalidPartOne(id int64) bool {
+	s := strconv.FormatInt(id, 10)
+
+	// Must have even length to split in half
+	if len(s)%2 != 0 {
+		return false
+	}
+
+	// No leading zeroes allowed
+	if s[0] == '0' {
+		return false
+	}
+
+	// Split in half and check if both halves are equal
+	mid := len(s) / 2
+	firstHalf := s[:mid]
+	secondHalf := s[mid:]
+
+	return firstHalf == secondHalf
+}
+
+// isInvalidPartTwo checks if a product ID is invalid (made of a sequence repeated at least twice)
+func isInvalidPartTwo(id int64) bool {
+	s := strconv.FormatInt(id, 10)
+
+	// No leading zeroes allowed
+	if s[0] == '0' {
+		return false
+	}
+
+	// Try all possible pattern lengths from 1 to len(s)/2
+	for patternLen := 1; patternLen <= len(s)/2; patternLen++ {
+		// Check if the string length is divisible by the pattern length
+		if len(s)%patternLen != 0 {
+			continue
+		}
+
+		// Extract the pattern
+		pattern := s[:patternLen]
+
+		// Check if the entire string consists of this pattern repeated
+		valid := true
+		for i := 0; i < len(s); i += patternLen {
+			if s[i:i+patternLen] != pattern {
+				valid = false
+				break
+			}
+		}
+
+		// If we found a valid repeating pattern, it's invalid
+		if valid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRanges parses the input line into a slice of ranges
+func parseRanges(line string) ([]Range, error) {
+	line = strings.TrimSpace(line)
+	parts := strings.Split(line, ",")
+
+	ranges := make([]Range, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		rangeParts := strings.Split(part, "-")
+		if len(rangeParts) != 2 {
+			return nil, fmt.Errorf("invalid range format: %s", part)
+		}
+
+		start, err := strconv.ParseInt(rangeParts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start value: %s", rangeParts[0])
+		}
+
+		end, err := strconv.ParseInt(rangeParts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end value: %s", rangeParts[1])
+		}
+
+		ranges = append(ranges, Range{Start: start, End: end})
+	}
+
+	return ranges, nil
+}
+
+// sumInvalidIDsPartOne finds all invalid IDs (Part One rules) in the given ranges and returns their sum
+func sumInvalidIDsPartOne(ranges []Range) int64 {
+	var sum int64
+
+	for _, r := range ranges {
+		for id := r.Start; id <= r.End; id++ {
+			if isInvalidPartOne(id) {
+				sum += id
+			}
+		}
+	}
+
+	return sum
+}
+
+// sumInvalidIDsPartTwo finds all invalid IDs (Part Two rules) in the given ranges and returns their sum
+func sumInvalidIDsPartTwo(ranges []Range) int64 {
+	var sum int64
+
+	for _, r := range ranges {
+		for id := r.Start; id <= r.End; id++ {
+			if isInvalidPartTwo(id) {
+				sum += id
+			}
+		}
+	}
+
+	return sum
+}
+
+// readRanges parses the single line of ranges.
+func readRanges(input []byte) ([]Range, error) {
+	scanner := aocinput.NewScanner(bytes.NewReader(input))
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("reading input: %v", scanner.Err())
+	}
+
+	line := scanner.Text()
+	ranges, err := parseRanges(line)
+	if err != nil {
+		return nil, fmt.Errorf("parsing ranges: %w", err)
+	}
+
+	return ranges, nil
+}
+
+// PartOne sums the IDs made of a sequence repeated twice.
+func PartOne(input []byte) (aocsolver.Answer, error) {
+	ranges, err := readRanges(input)
+	if err != nil {
+		return "", err
+	}
+
+	return aocsolver.Int(sumInvalidIDsPartOne(ranges)), nil
+}
+
+// PartTwo sums the IDs made of a sequence repeated at least twice.
+func PartTwo(input []byte) (aocsolver.Answer, error) {
+	ranges, err := readRanges(input)
+	if err != nil {
+		return "", err
+	}
+
+	return aocsolver.Int(sumInvalidIDsPartTwo(ranges)), nil
+}
+
+// Solve answers both parts.
+func Solve(input []byte) (aocsolver.Answer, aocsolver.Answer, error) {
+	return aocsolver.SolveParts(input, PartOne, PartTwo)
+}
@@ -0,0 +1,152 @@
+package puzzle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/IanShearer/aoc/aocinput"
+	"github.com/IanShearer/aoc/aocsolver"
+)
+
+type IDRange struct {
+	Min uint
+	Max uint
+
+	PartOneInvalidIDs []uint
+	PartTwoInvalidIDs []uint
+}
+
+func (r *IDRange) FindInvalidIDs() {
+	for i := r.Min; i <= r.Max; i++ {
+		s := strconv.FormatUint(uint64(i), 10)
+		half := len(s) / 2
+
+		// part one: has to be exactly half
+		if len(s)%2 == 0 {
+			if strings.EqualFold(s[half:], s[:half]) {
+				r.PartOneInvalidIDs = append(r.PartOneInvalidIDs, i)
+			}
+		}
+
+		// part two: all repeating numbers, any length
+		for j := 0; j <= half; j++ {
+			arr := splitIntoChunks(s, j)
+			uniq := slices.Compact(arr)
+			if len(arr) > 1 && len(uniq) == 1 {
+				r.PartTwoInvalidIDs = append(r.PartTwoInvalidIDs, i)
+				break
+			}
+		}
+	}
+}
+
+func splitIntoChunks(s string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	chunks := make([]string, 0)
+	for i := 0; i < len(s); i += n {
+		end := i + n
+		if end > len(s) {
+			end = len(s)
+		}
+		chunks = append(chunks, s[i:end])
+	}
+	return chunks
+}
+
+func NewIDRange(input string) (IDRange, error) {
+	idRange := IDRange{}
+
+	ids := strings.Split(input, "-")
+	if len(ids) != 2 {
+		return idRange, errors.New("invalid input")
+	}
+
+	min, err := strconv.ParseUint(ids[0], 10, 64)
+	if err != nil {
+		return idRange, err
+	}
+
+	max, err := strconv.ParseUint(ids[1], 10, 64)
+	if err != nil {
+		return idRange, err
+	}
+
+	idRange.Min = uint(min)
+	idRange.Max = uint(max)
+	idRange.PartOneInvalidIDs = make([]uint, 0)
+	idRange.PartTwoInvalidIDs = make([]uint, 0)
+
+	return idRange, nil
+}
+
+func ParseInput(input []byte) ([]IDRange, error) {
+	scanner := aocinput.NewScanner(bytes.NewReader(input))
+
+	ranges := make([]IDRange, 0)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		for r := range strings.SplitSeq(line, ",") {
+			newRange, err := NewIDRange(r)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, newRange)
+		}
+	}
+
+	return ranges, scanner.Err()
+}
+
+// sumInvalidIDs sums the invalid IDs of every range, by part.
+func sumInvalidIDs(input []byte) ([2]uint, error) {
+	ranges, err := ParseInput(input)
+	if err != nil {
+		return [2]uint{}, fmt.Errorf("failed to parse input: %w", err)
+	}
+
+	var sumPartOneInvalidIDs uint
+	var sumPartTwoInvalidIDs uint
+	for _, r := range ranges {
+		r.FindInvalidIDs()
+
+		for _, invalid := range r.PartOneInvalidIDs {
+			sumPartOneInvalidIDs += invalid
+		}
+
+		for _, invalid := range r.PartTwoInvalidIDs {
+			sumPartTwoInvalidIDs += invalid
+		}
+	}
+
+	return [2]uint{sumPartOneInvalidIDs, sumPartTwoInvalidIDs}, nil
+}
+
+func PartOne(input []byte) (aocsolver.Answer, error) {
+	sums, err := sumInvalidIDs(input)
+	if err != nil {
+		return "", err
+	}
+
+	return aocsolver.Int(sums[0]), nil
+}
+
+func PartTwo(input []byte) (aocsolver.Answer, error) {
+	sums, err := sumInvalidIDs(input)
+	if err != nil {
+		return "", err
+	}
+
+	return aocsolver.Int(sums[1]), nil
+}
+
+func Solve(input []byte) (aocsolver.Answer, aocsolver.Answer, error) {
+	return aocsolver.SolveParts(input, PartOne, PartTwo)
+}
@@ -0,0 +1,89 @@
+package puzzle
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/IanShearer/aoc/aocinput"
+	"github.com/IanShearer/aoc/aocsolver"
+)
+
+const maxNum byte = '9'
+
+func FindHighestJoltage(input string) int64 {
+	left := input[0]
+	right := byte('0')
+
+	for i, c := range input {
+		if i == 0 {
+			continue
+		}
+
+		if byte(c) > left && i != len(input)-1 {
+			left = byte(c)
+			right = byte('0')
+			continue
+		}
+
+		if byte(c) > right {
+			right = byte(c)
+		}
+	}
+
+	s := string([]byte{left, right})
+	num, _ := strconv.ParseInt(s, 10, 64)
+	return num
+}
+
+func FindHighestJoltageTwelveBatteries(input string) int64 {
+	batteries := make([]byte, 12)
+	findMaxForGivenIndex := func(start int, maxEnd int) (int, byte) {
+		// we start from the start index for the input, check for the largest number up until the len(input)-maxEnd
+		currentMax := byte('0')
+		currentMaxPostion := 0
+		for i := range len(input) - maxEnd - start {
+			if input[start+i] > currentMax {
+				currentMax = input[start+i]
+				currentMaxPostion = i
+			}
+
+			if currentMax == maxNum {
+				return start + i + 1, maxNum
+			}
+		}
+
+		return currentMaxPostion + start + 1, currentMax
+	}
+
+	currentPos := 0
+	for i := range batteries {
+		currentPos, batteries[i] = findMaxForGivenIndex(currentPos, len(batteries)-i-1)
+	}
+
+	s := string(batteries)
+	num, _ := strconv.ParseInt(s, 10, 64)
+	return num
+}
+
+// sumJoltage sums the highest joltage of each bank of the input.
+func sumJoltage(input []byte, highest func(bank string) int64) (aocsolver.Answer, error) {
+	scanner := aocinput.NewScanner(bytes.NewReader(input))
+	var sum int64
+	for scanner.Scan() {
+		sum += highest(scanner.Text())
+	}
+
+	return aocsolver.Int(sum), scanner.Err()
+}
+
+func PartOne(input []byte) (aocsolver.Answer, error) {
+	return sumJoltage(input, FindHighestJoltage)
+}
+
+func PartTwo(input []byte) (aocsolver.Answer, error) {
+	return sumJoltage(input, FindHighestJoltageTwelveBatteries)
+}
+
+func Solve(input []byte) (aocsolver.Answer, aocsolver.Answer, error) {
+	return aocsolver.SolveParts(input, PartOne, PartTwo)
+}
@@ -0,0 +1,160 @@
+package puzzle
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/IanShearer/aoc/aocinput"
+	"github.com/IanShearer/aoc/aocsolver"
+)
+
+// PartOne counts the rolls a forklift can get to.
+func PartOne(input []byte) (aocsolver.Answer, error) {
+	grid, err := readGrid(input)
+	if err != nil {
+		return "", fmt.Errorf("reading input: %w", err)
+	}
+
+	return aocsolver.Int(countAccessibleRolls(grid)), nil
+}
+
+// PartTwo counts the rolls removed until no more can be.
+func PartTwo(input []byte) (aocsolver.Answer, error) {
+	grid, err := readGrid(input)
+	if err != nil {
+		return "", fmt.Errorf("reading input: %w", err)
+	}
+
+	return aocsolver.Int(countRemovableRolls(grid)), nil
+}
+
+// Solve answers both parts.
+func Solve(input []byte) (aocsolver.Answer, aocsolver.Answer, error) {
+	return aocsolver.SolveParts(input, PartOne, PartTwo)
+}
+
+func readGrid(input []byte) ([]string, error) {
+	var grid []string
+	scanner := aocinput.NewScanner(bytes.NewReader(input))
+	for scanner.Scan() {
+		grid = append(grid, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return grid, nil
+}
+
+func countAccessibleRolls(grid []string) int {
+	if len(grid) == 0 {
+		return 0
+	}
+
+	count := 0
+	rows := len(grid)
+	cols := len(grid[0])
+
+	// Directions for 8 neighbors: up, down, left, right, and 4 diagonals
+	directions := [][2]int{
+		{-1, -1}, {-1, 0}, {-1, 1},
+		{0, -1}, {0, 1},
+		{1, -1}, {1, 0}, {1, 1},
+	}
+
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			if grid[row][col] != '@' {
+				continue
+			}
+
+			// Count adjacent rolls
+			adjacentRolls := 0
+			for _, dir := range directions {
+				newRow := row + dir[0]
+				newCol := col + dir[1]
+
+				if newRow >= 0 && newRow < rows && newCol >= 0 && newCol < cols {
+					if grid[newRow][newCol] == '@' {
+						adjacentRolls++
+					}
+				}
+			}
+
+			if adjacentRolls < 4 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func countRemovableRolls(grid []string) int {
+	// Create a mutable copy of the grid
+	mutableGrid := make([][]byte, len(grid))
+	for i, row := range grid {
+		mutableGrid[i] = []byte(row)
+	}
+
+	totalRemoved := 0
+
+	for {
+		accessible := findAccessibleRolls(mutableGrid)
+		if len(accessible) == 0 {
+			break
+		}
+
+		// Remove all accessible rolls
+		for _, pos := range accessible {
+			mutableGrid[pos[0]][pos[1]] = '.'
+		}
+
+		totalRemoved += len(accessible)
+	}
+
+	return totalRemoved
+}
+
+func findAccessibleRolls(grid [][]byte) [][2]int {
+	if len(grid) == 0 {
+		return nil
+	}
+
+	var accessible [][2]int
+	rows := len(grid)
+	cols := len(grid[0])
+
+	directions := [][2]int{
+		{-1, -1}, {-1, 0}, {-1, 1},
+		{0, -1}, {0, 1},
+		{1, -1}, {1, 0}, {1, 1},
+	}
+
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			if grid[row][col] != '@' {
+				continue
+			}
+
+			adjacentRolls := 0
+			for _, dir := range directions {
+				newRow := row + dir[0]
+				newCol := col + dir[1]
+
+				if newRow >= 0 && newRow < rows && newCol >= 0 && newCol < cols {
+					if grid[newRow][newCol] == '@' {
+						adjacentRolls++
+					}
+				}
+			}
+
+			if adjacentRolls < 4 {
+				accessible = append(accessible, [2]int{row, col})
+			}
+		}
+	}
+
+	return accessible
+}
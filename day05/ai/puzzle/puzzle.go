@@ -0,0 +1,134 @@
+package puzzle
+
+import (
+	"bufio"
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IanShearer/aoc/aocinput"
+	"github.com/IanShearer/aoc/aocsolver"
+)
+
+type Range struct {
+	start int
+	end   int
+}
+
+func (r Range) contains(id int) bool {
+	return id >= r.start && id <= r.end
+}
+
+func (r Range) size() int {
+	return r.end - r.start + 1
+}
+
+// mergeRanges takes a list of ranges and merges overlapping/adjacent ones
+func mergeRanges(ranges []Range) []Range {
+	if len(ranges) == 0 {
+		return ranges
+	}
+
+	// Sort ranges by start position
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+
+	merged := []Range{ranges[0]}
+	for i := 1; i < len(ranges); i++ {
+		last := &merged[len(merged)-1]
+		current := ranges[i]
+
+		// If current range overlaps or is adjacent to last merged range
+		if current.start <= last.end+1 {
+			// Merge by extending the end if necessary
+			if current.end > last.end {
+				last.end = current.end
+			}
+		} else {
+			// No overlap, add as new range
+			merged = append(merged, current)
+		}
+	}
+
+	return merged
+}
+
+// readRanges parses the ranges up to the blank line.
+func readRanges(scanner *bufio.Scanner) []Range {
+	var ranges []Range
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		parts := strings.Split(line, "-")
+		start, _ := strconv.Atoi(parts[0])
+		end, _ := strconv.Atoi(parts[1])
+		ranges = append(ranges, Range{start: start, end: end})
+	}
+
+	return ranges
+}
+
+// countFreshIDs counts the ingredient IDs left in the scanner that fall in
+// any range.
+func countFreshIDs(scanner *bufio.Scanner, ranges []Range) int {
+	freshCount := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		id, _ := strconv.Atoi(line)
+
+		// Check if ID is in any range
+		for _, r := range ranges {
+			if r.contains(id) {
+				freshCount++
+				break
+			}
+		}
+	}
+
+	return freshCount
+}
+
+// countAllFreshIDs counts all unique IDs in the merged ranges.
+func countAllFreshIDs(ranges []Range) int {
+	totalFreshIDs := 0
+	for _, r := range mergeRanges(ranges) {
+		totalFreshIDs += r.size()
+	}
+
+	return totalFreshIDs
+}
+
+func solve(scanner *bufio.Scanner) (int, int) {
+	ranges := readRanges(scanner)
+	totalFreshIDs := countAllFreshIDs(ranges)
+	freshCount := countFreshIDs(scanner, ranges)
+
+	return freshCount, totalFreshIDs
+}
+
+// PartOne counts the available ingredient IDs that are fresh.
+func PartOne(input []byte) (aocsolver.Answer, error) {
+	scanner := aocinput.NewScanner(bytes.NewReader(input))
+	ranges := readRanges(scanner)
+	freshCount := countFreshIDs(scanner, ranges)
+
+	return aocsolver.Int(freshCount), scanner.Err()
+}
+
+// PartTwo counts every ID the fresh ranges cover.
+func PartTwo(input []byte) (aocsolver.Answer, error) {
+	scanner := aocinput.NewScanner(bytes.NewReader(input))
+	ranges := readRanges(scanner)
+
+	return aocsolver.Int(countAllFreshIDs(ranges)), scanner.Err()
+}
+
+// Solve answers both parts from the ranges and the ingredient IDs.
+func Solve(input []byte) (aocsolver.Answer, aocsolver.Answer, error) {
+	return aocsolver.SolveParts(input, PartOne, PartTwo)
+}
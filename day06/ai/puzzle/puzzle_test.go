@@ -1,4 +1,4 @@
-package main
+package puzzle
 
 import (
 	"strings"
@@ -34,3 +34,15 @@ func TestPartTwoSample(t *testing.T) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+// A shorter number between two longer ones leaves a blank inside a column,
+// found by aoc difftest
+func TestPartTwoBlankInColumn(t *testing.T) {
+	lines := []string{"18", "5 ", "23", "* "}
+	result := solvePartTwo(lines)
+	expected := 12616 // 83 * 152
+
+	if result != expected {
+		t.Errorf("Expected %d, got %d", expected, result)
+	}
+}
@@ -0,0 +1,35 @@
+// Code generated by aoc create from the dayNN/<variant>/puzzle packages. DO NOT EDIT.
+
+package solvers
+
+import (
+	"github.com/IanShearer/aoc/aocsolver"
+
+	day01ai "github.com/IanShearer/aoc/day01/ai/puzzle"
+	day01human "github.com/IanShearer/aoc/day01/human/puzzle"
+	day02ai "github.com/IanShearer/aoc/day02/ai/puzzle"
+	day02human "github.com/IanShearer/aoc/day02/human/puzzle"
+	day03ai "github.com/IanShearer/aoc/day03/ai/puzzle"
+	day03human "github.com/IanShearer/aoc/day03/human/puzzle"
+	day04ai "github.com/IanShearer/aoc/day04/ai/puzzle"
+	day04human "github.com/IanShearer/aoc/day04/human/puzzle"
+	day05ai "github.com/IanShearer/aoc/day05/ai/puzzle"
+	day05human "github.com/IanShearer/aoc/day05/human/puzzle"
+	day06ai "github.com/IanShearer/aoc/day06/ai/puzzle"
+	day06human "github.com/IanShearer/aoc/day06/human/puzzle"
+)
+
+func init() {
+	aocsolver.Register(1, "ai", aocsolver.WithParts(aocsolver.SolverFunc(day01ai.Solve), day01ai.PartOne, day01ai.PartTwo))
+	aocsolver.Register(1, "human", aocsolver.WithParts(aocsolver.SolverFunc(day01human.Solve), day01human.PartOne, day01human.PartTwo))
+	aocsolver.Register(2, "ai", aocsolver.WithParts(aocsolver.SolverFunc(day02ai.Solve), day02ai.PartOne, day02ai.PartTwo))
+	aocsolver.Register(2, "human", aocsolver.WithParts(aocsolver.SolverFunc(day02human.Solve), day02human.PartOne, day02human.PartTwo))
+	aocsolver.Register(3, "ai", aocsolver.WithParts(aocsolver.SolverFunc(day03ai.Solve), day03ai.PartOne, day03ai.PartTwo))
+	aocsolver.Register(3, "human", aocsolver.WithParts(aocsolver.SolverFunc(day03human.Solve), day03human.PartOne, day03human.PartTwo))
+	aocsolver.Register(4, "ai", aocsolver.WithParts(aocsolver.SolverFunc(day04ai.Solve), day04ai.PartOne, day04ai.PartTwo))
+	aocsolver.Register(4, "human", aocsolver.WithParts(aocsolver.SolverFunc(day04human.Solve), day04human.PartOne, day04human.PartTwo))
+	aocsolver.Register(5, "ai", aocsolver.WithParts(aocsolver.SolverFunc(day05ai.Solve), day05ai.PartOne, day05ai.PartTwo))
+	aocsolver.Register(5, "human", aocsolver.WithParts(aocsolver.SolverFunc(day05human.Solve), day05human.PartOne, day05human.PartTwo))
+	aocsolver.Register(6, "ai", aocsolver.WithParts(aocsolver.SolverFunc(day06ai.Solve), day06ai.PartOne, day06ai.PartTwo))
+	aocsolver.Register(6, "human", aocsolver.WithParts(aocsolver.SolverFunc(day06human.Solve), day06human.PartOne, day06human.PartTwo))
+}
@@ -0,0 +1,6 @@
+// Package solvers registers every Go solution with aocsolver, importing it is
+// enough to look any of them up by day and variant.
+//
+// registry.go is generated by aoc create from the dayNN/<variant>/puzzle
+// packages.
+package solvers
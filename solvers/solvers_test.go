@@ -0,0 +1,132 @@
+package solvers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IanShearer/aoc/aocanswer"
+	"github.com/IanShearer/aoc/aocgen"
+	"github.com/IanShearer/aoc/aocinput"
+	"github.com/IanShearer/aoc/aocsolver"
+)
+
+// TestSolvers checks every registered solver against the answers of its
+// day, where the gitignored input and answers are around.
+func TestSolvers(t *testing.T) {
+	for _, day := range aocsolver.Days() {
+		dayDir := filepath.Join("..", fmt.Sprintf("day%02d", day))
+
+		input, err := os.ReadFile(filepath.Join(dayDir, "input"))
+		if err != nil {
+			continue
+		}
+		file, err := aocanswer.ReadFile(filepath.Join(dayDir, "answers"))
+		if err != nil {
+			continue
+		}
+		answers := file.Accepted()
+
+		for _, variant := range aocsolver.Variants(day) {
+			t.Run(fmt.Sprintf("day%02d/%s", day, variant), func(t *testing.T) {
+				solver, _ := aocsolver.Lookup(day, variant)
+				partOne, partTwo, err := solver.Solve(aocinput.Normalize(input))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				for i, got := range []aocsolver.Answer{partOne, partTwo} {
+					if i < len(answers) && answers[i] != "" && string(got) != answers[i] {
+						t.Errorf("part %d is wrong", i+1)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkSolvers times every registered solver on its day's input, or on
+// the input in AOC_INPUT, and each part on its own for the solvers that can
+// solve them apart. aoc bench runs it for one solver at a time.
+func BenchmarkSolvers(b *testing.B) {
+	for _, day := range aocsolver.Days() {
+		path := os.Getenv(aocinput.EnvVar)
+		if path == "" {
+			path = filepath.Join("..", fmt.Sprintf("day%02d", day), "input")
+		}
+		input, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		input = aocinput.Normalize(input)
+
+		b.Run(fmt.Sprintf("day%02d", day), func(b *testing.B) {
+			for _, variant := range aocsolver.Variants(day) {
+				solver, _ := aocsolver.Lookup(day, variant)
+				b.Run(variant, func(b *testing.B) {
+					benchSolver(b, solver, input)
+				})
+			}
+		})
+	}
+}
+
+func benchSolver(b *testing.B, solver aocsolver.Solver, input []byte) {
+	b.Run("solve", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, _, err := solver.Solve(input); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	parts, ok := solver.(aocsolver.PartSolver)
+	if !ok {
+		return
+	}
+	for part := 1; part <= 2; part++ {
+		b.Run(fmt.Sprintf("part%d", part), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := parts.Part(part, input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkScaling times every registered solver on generated inputs from
+// about the size of the real ones up to eight times bigger, so how it scales
+// shows next to how fast it is: go test -bench Scaling ./solvers
+func BenchmarkScaling(b *testing.B) {
+	for _, day := range aocsolver.Days() {
+		gen, ok := aocgen.Lookup(day)
+		if !ok {
+			continue
+		}
+
+		for _, variant := range aocsolver.Variants(day) {
+			solver, _ := aocsolver.Lookup(day, variant)
+			for _, scale := range []int{1, 2, 4, 8} {
+				size := gen.RealSize() * scale
+				b.Run(fmt.Sprintf("day%02d/%s/size=%d", day, variant, size), func(b *testing.B) {
+					input, err := aocgen.Generate(day, size, 1)
+					if err != nil {
+						b.Fatal(err)
+					}
+					input = aocinput.Normalize(input)
+
+					b.ReportAllocs()
+					for b.Loop() {
+						if _, _, err := solver.Solve(input); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}